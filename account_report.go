@@ -0,0 +1,411 @@
+package gads
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultAccountReportConcurrency = 4
+
+// AccountReportDownloader downloads the same report for every client
+// account under a manager account, the one of Auth.CustomerId.
+//
+// The accounts are found by walking the links returned by
+// ManagedCustomerService.Get, manager and test accounts are skipped.
+// Reports are downloaded in parallel and failed downloads retried, a
+// failure only affects its own account.
+//
+// Example
+//
+//   d := gads.NewAccountReportDownloader(&auth)
+//   d.Concurrency = 8
+//   summary, err := d.DownloadToDir(ctx, &gads.ReportDefinition{
+//     ReportName:     "campaigns",
+//     ReportType:     "CAMPAIGN_PERFORMANCE_REPORT",
+//     DateRangeType:  gads.DateRangeTypeYesterday,
+//     DownloadFormat: gads.DownloadFormatCSV,
+//     Selector:       gads.Selector{Fields: []string{"CampaignId", "Impressions"}},
+//   }, "reports")
+//   if err != nil {
+//     return err
+//   }
+//   for _, r := range summary.Failed() {
+//     log.Printf("%d: %v", r.Customer.CustomerID, r.Err)
+//   }
+//
+type AccountReportDownloader struct {
+	Auth
+	// Concurrency is the number of reports downloaded at the same time,
+	// defaults to 4.
+	Concurrency int
+	// Retry describes how failed downloads are retried, it defaults to
+	// Auth.Retry or to a default RetryPolicy when that one is nil too.
+	// Set MaxAttempts to 1 to disable retries.
+	Retry *RetryPolicy
+	// Timeout bounds every download attempt, defaults to 10 minutes. Like
+	// ReportDownloadOptions.Timeout it replaces Auth.Client.Timeout.
+	Timeout time.Duration
+	// Accept, when set, further filters the client accounts to download.
+	Accept func(ManagedCustomer) bool
+}
+
+// NewAccountReportDownloader is the AccountReportDownloader constructor
+func NewAccountReportDownloader(auth *Auth) *AccountReportDownloader {
+	return &AccountReportDownloader{Auth: *auth}
+}
+
+// AccountReportFunc receives the complete report of one account. It is
+// called again for the same account when it fails with a transient error
+// and the download is retried, so it must start over on every call.
+type AccountReportFunc func(ctx context.Context, customer ManagedCustomer, report io.Reader) error
+
+// AccountReportResult is the outcome of the download of one account.
+type AccountReportResult struct {
+	Customer ManagedCustomer
+	Attempts int
+	Duration time.Duration
+	Err      error
+}
+
+// AccountReportSummary lists the outcome of every account, ordered by
+// customer id.
+type AccountReportSummary struct {
+	Results []AccountReportResult
+}
+
+// Succeeded returns the results of the accounts downloaded.
+func (s *AccountReportSummary) Succeeded() (results []AccountReportResult) {
+	for _, r := range s.Results {
+		if r.Err == nil {
+			results = append(results, r)
+		}
+	}
+	return
+}
+
+// Failed returns the results of the accounts that couldn't be downloaded.
+func (s *AccountReportSummary) Failed() (results []AccountReportResult) {
+	for _, r := range s.Results {
+		if r.Err != nil {
+			results = append(results, r)
+		}
+	}
+	return
+}
+
+// Err joins the errors of the failed accounts, prefixed by their customer
+// id, nil when every account succeeded.
+func (s *AccountReportSummary) Err() error {
+	var errs []error
+	for _, r := range s.Failed() {
+		errs = append(errs, fmt.Errorf("customer %d: %w", r.Customer.CustomerID, r.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// Accounts returns the client accounts the reports are downloaded for.
+func (d *AccountReportDownloader) Accounts(ctx context.Context) ([]ManagedCustomer, error) {
+	mcs := ManagedCustomerService{Auth: d.Auth}
+	selector := Selector{
+		Fields: []string{"CustomerId", "Name", "CanManageClients", "TestAccount", "CurrencyCode", "DateTimeZone"},
+		Paging: &Paging{Offset: 0, Limit: 500},
+	}
+
+	var customers []ManagedCustomer
+	var links []ManagedCustomerLink
+	for {
+		page, pageLinks, totalCount, err := mcs.GetContext(ctx, selector)
+		if err != nil {
+			return nil, err
+		}
+		customers = append(customers, page...)
+		links = append(links, pageLinks...)
+		selector.Paging.Offset += selector.Paging.Limit
+		if len(page) == 0 || selector.Paging.Offset >= totalCount {
+			break
+		}
+	}
+
+	// walk down the tree from the root, when the api returned links
+	reachable := map[uint]bool{}
+	if root, err := strconv.ParseUint(strings.Replace(d.Auth.CustomerId, "-", "", -1), 10, 64); err == nil && len(links) > 0 {
+		children := map[uint][]uint{}
+		for _, l := range links {
+			children[l.ManagerCustomerID] = append(children[l.ManagerCustomerID], l.ClientCustomerId)
+		}
+		queue := []uint{uint(root)}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			for _, c := range children[id] {
+				if !reachable[c] {
+					reachable[c] = true
+					queue = append(queue, c)
+				}
+			}
+		}
+	}
+
+	var accounts []ManagedCustomer
+	for _, c := range customers {
+		switch {
+		case c.CanManageClients, c.TestAccount:
+		case len(reachable) > 0 && !reachable[c.CustomerID]:
+		case d.Accept != nil && !d.Accept(c):
+		default:
+			accounts = append(accounts, c)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].CustomerID < accounts[j].CustomerID })
+	return accounts, nil
+}
+
+// Download requests the report def for every account and hands the
+// reports to fn. The error is only about listing the accounts, the
+// failures of each account are in the summary.
+func (d *AccountReportDownloader) Download(ctx context.Context, def *ReportDefinition, fn AccountReportFunc) (*AccountReportSummary, error) {
+	if err := def.ValidRequest(); err != nil {
+		return nil, err
+	}
+	accounts, err := d.Accounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := d.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultAccountReportConcurrency
+	}
+	summary := &AccountReportSummary{Results: make([]AccountReportResult, len(accounts))}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, c := range accounts {
+		wg.Add(1)
+		go func(i int, c ManagedCustomer) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				summary.Results[i] = AccountReportResult{Customer: c, Err: ctx.Err()}
+				return
+			}
+			summary.Results[i] = d.download(ctx, *def, c, fn)
+		}(i, c)
+	}
+	wg.Wait()
+	return summary, nil
+}
+
+// download runs the report of one account, retrying transient failures.
+func (d *AccountReportDownloader) download(ctx context.Context, def ReportDefinition, c ManagedCustomer, fn AccountReportFunc) AccountReportResult {
+	retry := d.Retry
+	if retry == nil {
+		retry = d.Auth.Retry
+	}
+	if retry == nil {
+		retry = &RetryPolicy{}
+	}
+
+	def.ClientCustomerID = strconv.FormatUint(uint64(c.CustomerID), 10)
+	rds := ReportDefinitionService{Auth: d.Auth}
+	result := AccountReportResult{Customer: c}
+	start := time.Now()
+	for {
+		result.Attempts++
+		result.Err = d.downloadOnce(ctx, &rds, &def, c, fn)
+		if result.Err == nil || result.Attempts >= retry.maxAttempts() || ctx.Err() != nil ||
+			!isTransientReportError(result.Err) {
+			break
+		}
+		if err := retry.wait(ctx, result.Attempts, result.Err); err != nil {
+			break
+		}
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+func (d *AccountReportDownloader) downloadOnce(ctx context.Context, rds *ReportDefinitionService, def *ReportDefinition, c ManagedCustomer, fn AccountReportFunc) error {
+	tmp, err := os.CreateTemp("", "gads-report-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	// the attempts are retried by download
+	_, err = rds.download(ctx, def, tmp, &ReportDownloadOptions{
+		Timeout:        d.Timeout,
+		Retry:          &RetryPolicy{MaxAttempts: 1},
+		KeepCompressed: true,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return fn(ctx, c, tmp)
+}
+
+// isTransientReportError reports whether a failed report download is
+// worth retrying.
+func isTransientReportError(err error) bool {
+	var reportErr *ReportError
+	if errors.As(err, &reportErr) {
+		return reportErr.Temporary()
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return isTransientError(err)
+}
+
+// reportFileExtension returns the file extension matching format.
+func reportFileExtension(format DownloadFormat) string {
+	switch format {
+	case DownloadFormatCSV, DownloadFormatCSVForExcel:
+		return ".csv"
+	case DownloadFormatTSV:
+		return ".tsv"
+	case DownloadFormatXML:
+		return ".xml"
+	case DownloadFormatCSVGzipped:
+		return ".csv.gz"
+	case DownloadFormatXMLGzipped:
+		return ".xml.gz"
+	}
+	return ""
+}
+
+// DownloadToDir writes the report of every account in dir, in files
+// named after the customer id and the download format, like
+// "1234567890.csv".
+func (d *AccountReportDownloader) DownloadToDir(ctx context.Context, def *ReportDefinition, dir string) (*AccountReportSummary, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	ext := reportFileExtension(def.DownloadFormat)
+	return d.Download(ctx, def, func(ctx context.Context, c ManagedCustomer, report io.Reader) error {
+		name := filepath.Join(dir, strconv.FormatUint(uint64(c.CustomerID), 10)+ext)
+		f, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(f, report); err != nil {
+			f.Close()
+			os.Remove(name)
+			return err
+		}
+		return f.Close()
+	})
+}
+
+// DownloadMerged writes the reports of every account in w as a single
+// report, with the customer id as first column. Only CSV and TSV
+// reports, gzipped or not, can be merged; the report header and summary
+// are skipped and the column header of the first report is written once.
+//
+// Every report is buffered in a temporary file until complete so that a
+// retried download never writes rows twice.
+func (d *AccountReportDownloader) DownloadMerged(ctx context.Context, def *ReportDefinition, w io.Writer) (*AccountReportSummary, error) {
+	comma := ','
+	switch def.DownloadFormat {
+	case DownloadFormatCSV, DownloadFormatCSVGzipped:
+	case DownloadFormatTSV:
+		comma = '\t'
+	default:
+		return nil, fmt.Errorf("gads: can't merge %s reports", def.DownloadFormat)
+	}
+	merged := *def
+	merged.SkipHeader = true
+	merged.SkipSummary = true
+	// the first row of each report is taken as its column header
+	merged.SkipColumnHeader = false
+
+	var mu sync.Mutex
+	out := csv.NewWriter(w)
+	out.Comma = comma
+	headerWritten := false
+
+	summary, err := d.Download(ctx, &merged, func(ctx context.Context, c ManagedCustomer, report io.Reader) error {
+		tmp, err := os.CreateTemp("", "gads-report-*")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+
+		header, err := tagReportRows(report, tmp, comma, strconv.FormatUint(uint64(c.CustomerID), 10))
+		if err != nil {
+			return err
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if !headerWritten && header != nil {
+			if err := out.Write(append([]string{"Customer ID"}, header...)); err != nil {
+				return err
+			}
+			out.Flush()
+			headerWritten = true
+		}
+		_, err = io.Copy(w, tmp)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	out.Flush()
+	return summary, out.Error()
+}
+
+// tagReportRows copies the rows of report in w with customerId prepended,
+// and returns the column header.
+func tagReportRows(report io.Reader, w io.Writer, comma rune, customerId string) (header []string, err error) {
+	r, err := gunzipReport(report)
+	if err != nil {
+		return nil, err
+	}
+	in := csv.NewReader(r)
+	in.Comma = comma
+	in.FieldsPerRecord = -1
+	in.LazyQuotes = comma == '\t'
+
+	out := csv.NewWriter(w)
+	out.Comma = comma
+	for {
+		record, err := in.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			header = record
+			continue
+		}
+		if err := out.Write(append([]string{customerId}, record...)); err != nil {
+			return nil, err
+		}
+	}
+	out.Flush()
+	return header, out.Error()
+}
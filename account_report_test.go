@@ -0,0 +1,161 @@
+package gads
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testManagedCustomerGetResponse = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Header>
+    <ResponseHeader xmlns="https://adwords.google.com/api/adwords/cm/v201809">
+      <requestId>0005a1b2c3d7</requestId>
+    </ResponseHeader>
+  </soap:Header>
+  <soap:Body>
+    <getResponse xmlns="https://adwords.google.com/api/adwords/mcm/v201809">
+      <rval>
+        <totalNumEntries>6</totalNumEntries>
+        <entries><name>sub manager</name><customerId>111</customerId><canManageClients>true</canManageClients></entries>
+        <entries><name>nested client</name><customerId>222</customerId></entries>
+        <entries><name>flaky client</name><customerId>333</customerId></entries>
+        <entries><name>test client</name><customerId>444</customerId><testAccount>true</testAccount></entries>
+        <entries><name>broken client</name><customerId>555</customerId></entries>
+        <entries><name>unlinked client</name><customerId>666</customerId></entries>
+        <links><managerCustomerId>1234567890</managerCustomerId><clientCustomerId>111</clientCustomerId></links>
+        <links><managerCustomerId>111</managerCustomerId><clientCustomerId>222</clientCustomerId></links>
+        <links><managerCustomerId>1234567890</managerCustomerId><clientCustomerId>333</clientCustomerId></links>
+        <links><managerCustomerId>1234567890</managerCustomerId><clientCustomerId>444</clientCustomerId></links>
+        <links><managerCustomerId>1234567890</managerCustomerId><clientCustomerId>555</clientCustomerId></links>
+      </rval>
+    </getResponse>
+  </soap:Body>
+</soap:Envelope>`
+
+func testAccountReportDownloader(t *testing.T) (*AccountReportDownloader, map[string]int) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "ManagedCustomerService") {
+			fmt.Fprint(w, testManagedCustomerGetResponse)
+			return
+		}
+		if r.Header.Get("skipColumnHeader") != "false" {
+			t.Errorf("unexpected skipColumnHeader %q", r.Header.Get("skipColumnHeader"))
+		}
+		id := r.Header.Get("clientCustomerId")
+		mu.Lock()
+		calls[id]++
+		n := calls[id]
+		mu.Unlock()
+		switch {
+		case id == "333" && n == 1:
+			w.WriteHeader(http.StatusInternalServerError)
+		case id == "555":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `<reportDownloadError><ApiError><type>AuthorizationError.USER_PERMISSION_DENIED</type></ApiError></reportDownloadError>`)
+		default:
+			fmt.Fprintf(w, "Campaign ID,Impressions\n%s1,10\n%s2,20\n", id, id)
+		}
+	})
+
+	d := NewAccountReportDownloader(&auth)
+	d.Concurrency = 2
+	d.Retry = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	return d, calls
+}
+
+var testAccountReportDefinition = ReportDefinition{
+	ReportName:     "campaigns",
+	ReportType:     "CAMPAIGN_PERFORMANCE_REPORT",
+	DateRangeType:  DateRangeTypeYesterday,
+	DownloadFormat: DownloadFormatCSV,
+	Selector:       Selector{Fields: []string{"CampaignId", "Impressions"}},
+}
+
+func TestAccountReportAccounts(t *testing.T) {
+	d, _ := testAccountReportDownloader(t)
+	accounts, err := d.Accounts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []uint
+	for _, a := range accounts {
+		ids = append(ids, a.CustomerID)
+	}
+	if fmt.Sprint(ids) != "[222 333 555]" {
+		t.Errorf("unexpected accounts %v", ids)
+	}
+}
+
+func TestAccountReportDownloadToDir(t *testing.T) {
+	d, calls := testAccountReportDownloader(t)
+	dir := t.TempDir()
+	def := testAccountReportDefinition
+	summary, err := d.DownloadToDir(context.Background(), &def, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(summary.Results) != 3 || len(summary.Succeeded()) != 2 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	failed := summary.Failed()
+	if len(failed) != 1 || failed[0].Customer.CustomerID != 555 || failed[0].Attempts != 1 {
+		t.Errorf("expected 555 to fail without retry, got %+v", failed)
+	}
+	if err := summary.Err(); err == nil || !strings.Contains(err.Error(), "customer 555: ") {
+		t.Errorf("unexpected summary error %v", err)
+	}
+	if calls["333"] != 2 || summary.Results[1].Attempts != 2 {
+		t.Errorf("expected 333 to be retried once, got %d calls", calls["333"])
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "333.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "Campaign ID,Impressions\n3331,10\n3332,20\n" {
+		t.Errorf("unexpected report %q", b)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "555.csv")); !os.IsNotExist(err) {
+		t.Errorf("expected no report for the failed account, got %v", err)
+	}
+}
+
+func TestAccountReportDownloadMerged(t *testing.T) {
+	d, _ := testAccountReportDownloader(t)
+	d.Accept = func(c ManagedCustomer) bool { return c.CustomerID != 555 }
+	var buf bytes.Buffer
+	def := testAccountReportDefinition
+	def.SkipColumnHeader = true
+	summary, err := d.DownloadMerged(context.Background(), &def, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := summary.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 || lines[0] != "Customer ID,Campaign ID,Impressions" {
+		t.Fatalf("unexpected merged report\n%s", buf.String())
+	}
+	for _, want := range []string{"222,2221,10", "333,3332,20"} {
+		if !strings.Contains(buf.String(), want+"\n") {
+			t.Errorf("expected %q in the merged report\n%s", want, buf.String())
+		}
+	}
+
+	def.DownloadFormat = DownloadFormatXML
+	if _, err := d.DownloadMerged(context.Background(), &def, &buf); err == nil {
+		t.Error("expected xml reports not to be mergeable")
+	}
+}
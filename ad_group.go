@@ -1,6 +1,9 @@
 package gads
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 type AdGroupService struct {
 	Auth
@@ -65,6 +68,30 @@ type AdGroupLabel struct {
 
 type AdGroupLabelOperations map[string][]AdGroupLabel
 
+// AdGroupFields is the catalog of the fields accepted by the selectors of
+// AdGroupService.Get, see NewSelector.
+var AdGroupFields = FieldCatalog{
+	Service: "AdGroupService",
+	Selectable: []string{
+		"Id", "CampaignId", "CampaignName", "Name", "Status", "Settings", "Labels",
+		"ContentBidCriterionTypeGroup", "TrackingUrlTemplate", "FinalUrlSuffix", "UrlCustomParameters",
+		"AdGroupType", "AdRotationMode", "BaseCampaignId", "BaseAdGroupId",
+		// BiddingStrategyConfiguration
+		"BiddingStrategyId", "BiddingStrategyName", "BiddingStrategySource", "BiddingStrategyType",
+		"CpcBid", "CpmBid", "CpvBid", "EnhancedCpcEnabled", "TargetCpa", "TargetCpaBid",
+		"TargetCpaBidSource", "TargetRoasOverride",
+	},
+	Filterable: []string{
+		"Id", "CampaignId", "CampaignName", "Name", "Status", "Labels",
+		"ContentBidCriterionTypeGroup", "TrackingUrlTemplate", "FinalUrlSuffix",
+		"AdGroupType", "AdRotationMode", "BaseCampaignId", "BaseAdGroupId",
+		"BiddingStrategyId", "BiddingStrategyName", "BiddingStrategySource", "BiddingStrategyType",
+		"CpcBid", "CpmBid", "CpvBid", "EnhancedCpcEnabled", "TargetCpa", "TargetCpaBid",
+		"TargetCpaBidSource", "TargetRoasOverride",
+	},
+	Complete: true,
+}
+
 // Get returns an array of ad group's and the total number of ad group's matching
 // the selector.
 //
@@ -103,8 +130,14 @@ type AdGroupLabelOperations map[string][]AdGroupLabel
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupService#get
 //
 func (s *AdGroupService) Get(selector Selector) (adGroups []AdGroup, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *AdGroupService) GetContext(ctx context.Context, selector Selector) (adGroups []AdGroup, totalCount int64, err error) {
 	selector.XMLName = xml.Name{"", "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		adGroupServiceUrl,
 		"get",
 		struct {
@@ -132,6 +165,38 @@ func (s *AdGroupService) Get(selector Selector) (adGroups []AdGroup, totalCount
 	return getResp.AdGroups, getResp.Size, err
 }
 
+// ForEach streams the ad groups matching the selector to fn, decoding them
+// one at a time from the response instead of holding the whole page in
+// memory. Returning ErrStopStream from fn stops the stream without error,
+// any other error is returned by ForEach.
+//
+// Example
+//
+//   totalCount, err := adGroupService.ForEach(ctx, selector, func(adGroup AdGroup) error {
+//     fmt.Println(adGroup.Id)
+//     return nil
+//   })
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupService#get
+//
+func (s *AdGroupService) ForEach(ctx context.Context, selector Selector, fn func(AdGroup) error) (totalCount int64, err error) {
+	return streamGet[[]AdGroup](ctx, &s.Auth, adGroupServiceUrl, "get", getSelector(adGroupServiceUrl, selector), fn)
+}
+
+// adGroupOperand clears the fields of adGroup the api refuses in a
+// mutate.
+func adGroupOperand(adGroup AdGroup) AdGroup {
+	for i := range adGroup.BiddingStrategyConfiguration {
+		// this field is not mutable and will throw an error
+		adGroup.BiddingStrategyConfiguration[i].StrategyType = ""
+		adGroup.BiddingStrategyConfiguration[i].Scheme = nil
+		adGroup.BiddingStrategyConfiguration[i].StrategyId = 0
+	}
+	return adGroup
+}
+
 // Mutate allows you to add, modify and remove ad group's, returning the
 // modified ad group's.
 //
@@ -171,6 +236,11 @@ func (s *AdGroupService) Get(selector Selector) (adGroups []AdGroup, totalCount
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupService#mutate
 //
 func (s *AdGroupService) Mutate(adGroupOperations AdGroupOperations) (adGroups []AdGroup, err error) {
+	return s.MutateContext(context.Background(), adGroupOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *AdGroupService) MutateContext(ctx context.Context, adGroupOperations AdGroupOperations) (adGroups []AdGroup, err error) {
 	type adGroupOperation struct {
 		Action  string  `xml:"operator"`
 		AdGroup AdGroup `xml:"operand"`
@@ -178,16 +248,10 @@ func (s *AdGroupService) Mutate(adGroupOperations AdGroupOperations) (adGroups [
 	operations := []adGroupOperation{}
 	for action, adGroups := range adGroupOperations {
 		for _, adGroup := range adGroups {
-			for i := range adGroup.BiddingStrategyConfiguration {
-				// this field is not mutable and will throw an error
-				adGroup.BiddingStrategyConfiguration[i].StrategyType = ""
-				adGroup.BiddingStrategyConfiguration[i].Scheme = nil
-				adGroup.BiddingStrategyConfiguration[i].StrategyId = 0
-			}
 			operations = append(operations,
 				adGroupOperation{
 					Action:  action,
-					AdGroup: adGroup,
+					AdGroup: adGroupOperand(adGroup),
 				},
 			)
 		}
@@ -202,7 +266,7 @@ func (s *AdGroupService) Mutate(adGroupOperations AdGroupOperations) (adGroups [
 		},
 		Ops: operations,
 	}
-	respBody, err := s.Auth.request(adGroupServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, adGroupServiceUrl, "mutate", mutation)
 	if err != nil {
 		return adGroups, err
 	}
@@ -242,6 +306,11 @@ func (s *AdGroupService) Mutate(adGroupOperations AdGroupOperations) (adGroups [
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupService#mutateLabel
 //
 func (s *AdGroupService) MutateLabel(adGroupLabelOperations AdGroupLabelOperations) (adGroupLabels []AdGroupLabel, err error) {
+	return s.MutateLabelContext(context.Background(), adGroupLabelOperations)
+}
+
+// MutateLabelContext is like MutateLabel but binds the request to ctx.
+func (s *AdGroupService) MutateLabelContext(ctx context.Context, adGroupLabelOperations AdGroupLabelOperations) (adGroupLabels []AdGroupLabel, err error) {
 	type adGroupLabelOperation struct {
 		Action       string       `xml:"operator"`
 		AdGroupLabel AdGroupLabel `xml:"operand"`
@@ -266,7 +335,7 @@ func (s *AdGroupService) MutateLabel(adGroupLabelOperations AdGroupLabelOperatio
 			Local: "mutateLabel",
 		},
 		Ops: operations}
-	respBody, err := s.Auth.request(adGroupServiceUrl, "mutateLabel", mutation)
+	respBody, err := s.Auth.request(ctx, adGroupServiceUrl, "mutateLabel", mutation)
 	if err != nil {
 		return adGroupLabels, err
 	}
@@ -286,12 +355,45 @@ func (s *AdGroupService) MutateLabel(adGroupLabelOperations AdGroupLabelOperatio
 	return mutateResp.AdGroupLabels, err
 }
 
-// Query is not yet implemented
+// Query returns the ad groups matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   adGroups, err := adGroupService.Query("SELECT Id, Name WHERE CampaignId = '123' ORDER BY Name")
 //
 // Relevant documentation
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupService#query
 //
 func (s *AdGroupService) Query(query string) (adGroups []AdGroup, err error) {
-	return adGroups, ERROR_NOT_YET_IMPLEMENTED
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *AdGroupService) QueryContext(ctx context.Context, query string) (adGroups []AdGroup, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		adGroupServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return adGroups, err
+	}
+	queryResp := struct {
+		Size     int64     `xml:"rval>totalNumEntries"`
+		AdGroups []AdGroup `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return adGroups, err
+	}
+	return queryResp.AdGroups, err
 }
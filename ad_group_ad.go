@@ -1,6 +1,9 @@
 package gads
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 type AdGroupAdService struct {
 	Auth
@@ -66,6 +69,32 @@ type AdGroupAdLabel struct {
 
 type AdGroupAdLabelOperations map[string][]AdGroupAdLabel
 
+// AdGroupAdFields is the catalog of the fields accepted by the selectors of
+// AdGroupAdService.Get, see NewSelector.
+var AdGroupAdFields = FieldCatalog{
+	Service: "AdGroupAdService",
+	Selectable: []string{
+		"AdGroupId", "Id", "Url", "DisplayUrl", "CreativeFinalUrls", "CreativeFinalMobileUrls",
+		"CreativeFinalAppUrls", "CreativeTrackingUrlTemplate", "CreativeUrlCustomParameters",
+		"DevicePreference", "Status", "AdGroupCreativeApprovalStatus", "AdGroupAdDisapprovalReasons",
+		"AdGroupAdTrademarkDisapproved", "Labels",
+		// TextAd
+		"Headline", "Description1", "Description2",
+		// ImageAd
+		"ImageCreativeName",
+	},
+	Filterable: []string{
+		"AdGroupId", "Id", "Url", "DisplayUrl", "CreativeFinalUrls", "CreativeFinalMobileUrls",
+		"CreativeFinalAppUrls", "CreativeTrackingUrlTemplate", "CreativeUrlCustomParameters",
+		"DevicePreference", "Status", "AdGroupCreativeApprovalStatus", "AdGroupAdDisapprovalReasons",
+		"Labels",
+		// TextAd
+		"Headline", "Description1", "Description2",
+		// ImageAd
+		"ImageCreativeName",
+	},
+}
+
 // Get returns an array of ad's and the total number of ad's matching
 // the selector.
 //
@@ -115,8 +144,14 @@ type AdGroupAdLabelOperations map[string][]AdGroupAdLabel
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupAdService#get
 //
 func (s AdGroupAdService) Get(selector Selector) (adGroupAds AdGroupAds, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s AdGroupAdService) GetContext(ctx context.Context, selector Selector) (adGroupAds AdGroupAds, totalCount int64, err error) {
 	selector.XMLName = xml.Name{"", "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		adGroupAdServiceUrl,
 		"get",
 		struct {
@@ -144,6 +179,26 @@ func (s AdGroupAdService) Get(selector Selector) (adGroupAds AdGroupAds, totalCo
 	return getResp.AdGroupAds, getResp.Size, err
 }
 
+// ForEach streams the ad group ads matching the selector to fn, decoding them
+// one at a time from the response instead of holding the whole page in
+// memory. Returning ErrStopStream from fn stops the stream without error,
+// any other error is returned by ForEach.
+//
+// Example
+//
+//   totalCount, err := adGroupAdService.ForEach(ctx, selector, func(adGroupAd AdGroupAd) error {
+//     fmt.Println(adGroupAd.AdGroupId, adGroupAd.Status)
+//     return nil
+//   })
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupAdService#get
+//
+func (s AdGroupAdService) ForEach(ctx context.Context, selector Selector, fn func(AdGroupAd) error) (totalCount int64, err error) {
+	return streamGet[AdGroupAds](ctx, &s.Auth, adGroupAdServiceUrl, "get", getSelector(adGroupAdServiceUrl, selector), fn)
+}
+
 // Mutate allows you to add, modify and remove ads, returning the
 // modified ads.
 //
@@ -176,6 +231,11 @@ func (s AdGroupAdService) Get(selector Selector) (adGroupAds AdGroupAds, totalCo
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupAdService#mutate
 //
 func (s *AdGroupAdService) Mutate(adGroupAdOperations AdGroupAdOperations) (adGroupAds AdGroupAds, err error) {
+	return s.MutateContext(context.Background(), adGroupAdOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *AdGroupAdService) MutateContext(ctx context.Context, adGroupAdOperations AdGroupAdOperations) (adGroupAds AdGroupAds, err error) {
 	type adGroupAdOperation struct {
 		Action    string    `xml:"operator"`
 		AdGroupAd AdGroupAd `xml:"operand"`
@@ -201,7 +261,7 @@ func (s *AdGroupAdService) Mutate(adGroupAdOperations AdGroupAdOperations) (adGr
 		},
 		Ops: operations,
 	}
-	respBody, err := s.Auth.request(adGroupAdServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, adGroupAdServiceUrl, "mutate", mutation)
 	if err != nil {
 		return adGroupAds, err
 	}
@@ -241,6 +301,11 @@ func (s *AdGroupAdService) Mutate(adGroupAdOperations AdGroupAdOperations) (adGr
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupAdService#mutateLabel
 //
 func (s *AdGroupAdService) MutateLabel(adGroupAdLabelOperations AdGroupAdLabelOperations) (adGroupAdLabels []AdGroupAdLabel, err error) {
+	return s.MutateLabelContext(context.Background(), adGroupAdLabelOperations)
+}
+
+// MutateLabelContext is like MutateLabel but binds the request to ctx.
+func (s *AdGroupAdService) MutateLabelContext(ctx context.Context, adGroupAdLabelOperations AdGroupAdLabelOperations) (adGroupAdLabels []AdGroupAdLabel, err error) {
 	type adGroupAdLabelOperation struct {
 		Action         string         `xml:"operator"`
 		AdGroupAdLabel AdGroupAdLabel `xml:"operand"`
@@ -265,7 +330,7 @@ func (s *AdGroupAdService) MutateLabel(adGroupAdLabelOperations AdGroupAdLabelOp
 			Local: "mutateLabel",
 		},
 		Ops: operations}
-	respBody, err := s.Auth.request(adGroupAdServiceUrl, "mutateLabel", mutation)
+	respBody, err := s.Auth.request(ctx, adGroupAdServiceUrl, "mutateLabel", mutation)
 	if err != nil {
 		return adGroupAdLabels, err
 	}
@@ -280,12 +345,45 @@ func (s *AdGroupAdService) MutateLabel(adGroupAdLabelOperations AdGroupAdLabelOp
 	return mutateResp.AdGroupAdLabels, err
 }
 
-// Query is not yet implemented
+// Query returns the ad group ads matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   adGroupAds, totalCount, err := adGroupAdService.Query("SELECT Id, Status WHERE AdGroupId = '123'")
 //
 // Relevant documentation
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupAdService#query
 //
 func (s *AdGroupAdService) Query(query string) (adGroupAds AdGroupAds, totalCount int64, err error) {
-	return adGroupAds, totalCount, ERROR_NOT_YET_IMPLEMENTED
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *AdGroupAdService) QueryContext(ctx context.Context, query string) (adGroupAds AdGroupAds, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		adGroupAdServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return adGroupAds, totalCount, err
+	}
+	queryResp := struct {
+		Size       int64      `xml:"rval>totalNumEntries"`
+		AdGroupAds AdGroupAds `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return adGroupAds, totalCount, err
+	}
+	return queryResp.AdGroupAds, queryResp.Size, err
 }
@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 	"encoding/xml"
 	//	"fmt"
 )
@@ -61,8 +62,14 @@ func (cc *AdGroupBidModifier) UnmarshalXML(dec *xml.Decoder, start xml.StartElem
 
 // Get returns budgets matching a given selector and the total count of matching budgets.
 func (s *AdGroupBidModifierService) Get(selector Selector) (bm []AdGroupBidModifier, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *AdGroupBidModifierService) GetContext(ctx context.Context, selector Selector) (bm []AdGroupBidModifier, totalCount int64, err error) {
 	selector.XMLName = xml.Name{"", "selector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		adGroupBidModifierServiceUrl,
 		"get",
 		struct {
@@ -92,6 +99,11 @@ func (s *AdGroupBidModifierService) Get(selector Selector) (bm []AdGroupBidModif
 
 // Mutate takes a budgetOperations and creates, modifies or destroys the associated budgets.
 func (s *AdGroupBidModifierService) Mutate(bidmOperations AdGroupBidModifierOperations) (resp []AdGroupBidModifier, err error) {
+	return s.MutateContext(context.Background(), bidmOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *AdGroupBidModifierService) MutateContext(ctx context.Context, bidmOperations AdGroupBidModifierOperations) (resp []AdGroupBidModifier, err error) {
 	type bidmOperation struct {
 		Action             string             `xml:"operator"`
 		AdGroupBidModifier AdGroupBidModifier `xml:"operand"`
@@ -103,6 +115,7 @@ func (s *AdGroupBidModifierService) Mutate(bidmOperations AdGroupBidModifierOper
 		}
 	}
 	respBody, err := s.Auth.request(
+		ctx,
 		adGroupBidModifierServiceUrl,
 		"mutate",
 		struct {
@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 )
@@ -66,6 +67,52 @@ func (agcs *AdGroupCriterions) UnmarshalXML(dec *xml.Decoder, start xml.StartEle
 
 type AdGroupCriterionOperations map[string]AdGroupCriterions
 
+// AdGroupCriterionFields is the catalog of the fields accepted by the selectors of
+// AdGroupCriterionService.Get, see NewSelector.
+var AdGroupCriterionFields = FieldCatalog{
+	Service: "AdGroupCriterionService",
+	Selectable: []string{
+		"AdGroupId", "CriterionUse", "Id", "CriteriaType", "Labels",
+		// AgeRange
+		"AgeRangeType",
+		// AppPaymentModel
+		"AppPaymentModelType",
+		// CriterionUserInterest
+		"UserInterestId", "UserInterestName",
+		// CriterionUserList
+		"UserListId", "UserListName", "UserListMembershipStatus",
+		// Gender
+		"GenderType",
+		// Keyword
+		"KeywordText", "KeywordMatchType",
+		// MobileAppCategory
+		"MobileAppCategoryId",
+		// MobileApplication
+		"DisplayName",
+		// Placement
+		"PlacementUrl",
+		// Product
+		"Text",
+		// ProductPartition
+		"PartitionType", "ParentCriterionId", "CaseValue",
+		// Vertical
+		"VerticalId", "VerticalParentId", "Path",
+		// Webpage
+		"Parameter", "CriteriaCoverage", "CriteriaSamples",
+	},
+	Filterable: []string{
+		"AdGroupId", "CriterionUse", "Id", "CriteriaType", "Labels",
+		// CriterionUserList
+		"UserListMembershipStatus",
+		// Keyword
+		"KeywordText", "KeywordMatchType",
+		// MobileApplication
+		"DisplayName",
+		// Placement
+		"PlacementUrl",
+	},
+}
+
 // Get returns an array of AdGroupCriterion's and the total number of AdGroupCriterion's matching
 // the selector.
 //
@@ -143,8 +190,14 @@ type AdGroupCriterionOperations map[string]AdGroupCriterions
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupCriterionService#get
 //
 func (s AdGroupCriterionService) Get(selector Selector) (adGroupCriterions AdGroupCriterions, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s AdGroupCriterionService) GetContext(ctx context.Context, selector Selector) (adGroupCriterions AdGroupCriterions, totalCount int64, err error) {
 	selector.XMLName = xml.Name{"", "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		adGroupCriterionServiceUrl,
 		"get",
 		struct {
@@ -172,6 +225,47 @@ func (s AdGroupCriterionService) Get(selector Selector) (adGroupCriterions AdGro
 	return getResp.AdGroupCriterions, getResp.Size, err
 }
 
+// ForEach streams the ad group criterions matching the selector to fn, decoding them
+// one at a time from the response instead of holding the whole page in
+// memory. Returning ErrStopStream from fn stops the stream without error,
+// any other error is returned by ForEach.
+//
+// Example
+//
+//   totalCount, err := adGroupCriterionService.ForEach(ctx, selector, func(adGroupCriterion interface{}) error {
+//     switch c := adGroupCriterion.(type) {
+//     case gads.BiddableAdGroupCriterion:
+//       fmt.Println(c.AdGroupId, c.UserStatus)
+//     }
+//     return nil
+//   })
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupCriterionService#get
+//
+func (s AdGroupCriterionService) ForEach(ctx context.Context, selector Selector, fn func(interface{}) error) (totalCount int64, err error) {
+	return streamGet[AdGroupCriterions](ctx, &s.Auth, adGroupCriterionServiceUrl, "get", getSelector(adGroupCriterionServiceUrl, selector), fn)
+}
+
+// adGroupCriterionOperand clears the fields of adGroupCriterion the api
+// refuses in a mutate.
+func adGroupCriterionOperand(adGroupCriterion interface{}) interface{} {
+	// fields are prohibited
+	if t, ok := adGroupCriterion.(BiddableAdGroupCriterion); ok {
+		if t.BiddingStrategyConfiguration != nil {
+			t.BiddingStrategyConfiguration.Scheme = nil
+			// Can't set any value except NONE on Keyword Criterion
+			// https://developers.google.com/adwords/api/docs/guides/bidding#migrating_the_bidding_strategy_configuration_override_of_ad_groups_and_keywords
+			if t.Type != "Keyword" || t.BiddingStrategyConfiguration.StrategyType != "NONE" {
+				t.BiddingStrategyConfiguration.StrategyType = ""
+			}
+			t.BiddingStrategyConfiguration.StrategyId = 0
+		}
+	}
+	return adGroupCriterion
+}
+
 // Mutate allows you to add, modify and remove ad group criterion, returning the
 // modified ad group criterion.
 //
@@ -204,6 +298,11 @@ func (s AdGroupCriterionService) Get(selector Selector) (adGroupCriterions AdGro
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupCriterionService#mutate
 //
 func (s *AdGroupCriterionService) Mutate(adGroupCriterionOperations AdGroupCriterionOperations) (adGroupCriterions AdGroupCriterions, err error) {
+	return s.MutateContext(context.Background(), adGroupCriterionOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *AdGroupCriterionService) MutateContext(ctx context.Context, adGroupCriterionOperations AdGroupCriterionOperations) (adGroupCriterions AdGroupCriterions, err error) {
 	type adGroupCriterionOperation struct {
 		Action           string      `xml:"operator"`
 		AdGroupCriterion interface{} `xml:"operand"`
@@ -214,24 +313,10 @@ func (s *AdGroupCriterionService) Mutate(adGroupCriterionOperations AdGroupCrite
 	for _, action := range []string{"REMOVE", "ADD", "SET"} {
 		if _, ok := adGroupCriterionOperations[action]; ok {
 			for _, adGroupCriterion := range adGroupCriterionOperations[action] {
-
-				// fields are prohibited
-				if t, ok := adGroupCriterion.(BiddableAdGroupCriterion); ok {
-					if t.BiddingStrategyConfiguration != nil {
-						t.BiddingStrategyConfiguration.Scheme = nil
-						// Can't set any value except NONE on Keyword Criterion
-						// https://developers.google.com/adwords/api/docs/guides/bidding#migrating_the_bidding_strategy_configuration_override_of_ad_groups_and_keywords
-						if t.Type != "Keyword" || t.BiddingStrategyConfiguration.StrategyType != "NONE" {
-							t.BiddingStrategyConfiguration.StrategyType = ""
-						}
-						t.BiddingStrategyConfiguration.StrategyId = 0
-					}
-				}
-
 				operations = append(operations,
 					adGroupCriterionOperation{
 						Action:           action,
-						AdGroupCriterion: adGroupCriterion,
+						AdGroupCriterion: adGroupCriterionOperand(adGroupCriterion),
 					},
 				)
 			}
@@ -247,7 +332,7 @@ func (s *AdGroupCriterionService) Mutate(adGroupCriterionOperations AdGroupCrite
 		},
 		Ops: operations,
 	}
-	respBody, err := s.Auth.request(adGroupCriterionServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, adGroupCriterionServiceUrl, "mutate", mutation)
 	if err != nil {
 		return adGroupCriterions, err
 	}
@@ -288,6 +373,11 @@ func (s *AdGroupCriterionService) Mutate(adGroupCriterionOperations AdGroupCrite
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupCriterionService#mutateLabel
 //
 func (s *AdGroupCriterionService) MutateLabel(adGroupCriterionLabelOperations AdGroupCriterionLabelOperations) (adGroupCriterionLabels []AdGroupCriterionLabel, err error) {
+	return s.MutateLabelContext(context.Background(), adGroupCriterionLabelOperations)
+}
+
+// MutateLabelContext is like MutateLabel but binds the request to ctx.
+func (s *AdGroupCriterionService) MutateLabelContext(ctx context.Context, adGroupCriterionLabelOperations AdGroupCriterionLabelOperations) (adGroupCriterionLabels []AdGroupCriterionLabel, err error) {
 	type adGroupCriterionLabelOperation struct {
 		Action                string                `xml:"operator"`
 		AdGroupCriterionLabel AdGroupCriterionLabel `xml:"operand"`
@@ -312,7 +402,7 @@ func (s *AdGroupCriterionService) MutateLabel(adGroupCriterionLabelOperations Ad
 			Local: "mutateLabel",
 		},
 		Ops: operations}
-	respBody, err := s.Auth.request(adGroupCriterionServiceUrl, "mutateLabel", mutation)
+	respBody, err := s.Auth.request(ctx, adGroupCriterionServiceUrl, "mutateLabel", mutation)
 	if err != nil {
 		return adGroupCriterionLabels, err
 	}
@@ -332,12 +422,45 @@ func (s *AdGroupCriterionService) MutateLabel(adGroupCriterionLabelOperations Ad
 	return mutateResp.AdGroupCriterionLabels, err
 }
 
-// Query is not yet implemented
+// Query returns the ad group criterions matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   adGroupCriterions, err := adGroupCriterionService.Query("SELECT Id, KeywordText WHERE AdGroupId = '123'")
 //
 // Relevant documentation
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupCriterionService#query
 //
 func (s *AdGroupCriterionService) Query(query string) (adGroupCriterions AdGroupCriterions, err error) {
-	return adGroupCriterions, ERROR_NOT_YET_IMPLEMENTED
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *AdGroupCriterionService) QueryContext(ctx context.Context, query string) (adGroupCriterions AdGroupCriterions, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		adGroupCriterionServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return adGroupCriterions, err
+	}
+	queryResp := struct {
+		Size              int64             `xml:"rval>totalNumEntries"`
+		AdGroupCriterions AdGroupCriterions `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return adGroupCriterions, err
+	}
+	return queryResp.AdGroupCriterions, err
 }
@@ -1,8 +1,8 @@
 package gads
 
 import (
-//	"encoding/xml"
-//	"fmt"
+	"context"
+	"encoding/xml"
 )
 
 type AdGroupFeedService struct {
@@ -13,38 +13,214 @@ func NewAdGroupFeedService(auth *Auth) *AdGroupFeedService {
 	return &AdGroupFeedService{Auth: *auth}
 }
 
-type AdGroupFeedOperations struct {
+// AdGroupFeed attaches a feed to an ad group, the items of the feed
+// matching MatchingFunction serving as the PlaceholderTypes extensions of
+// the ad group.
+type AdGroupFeed struct {
+	FeedId           int64    `xml:"feedId"`
+	AdGroupId        int64    `xml:"adGroupId"`
+	MatchingFunction Function `xml:"matchingFunction"`
+	PlaceholderTypes []int64  `xml:"placeholderTypes"`
+	Status           string   `xml:"status,omitempty"` // Status: "ENABLED", "REMOVED"
+	BaseCampaignId   int64    `xml:"baseCampaignId,omitempty"`
+	BaseAdGroupId    int64    `xml:"baseAdGroupId,omitempty"`
 }
 
-type AdGroupFeed struct {
+// AdGroupFeedOperations is a map of operations to perform on AdGroupFeed's
+type AdGroupFeedOperations map[string][]AdGroupFeed
+
+// AdGroupFeedFields is the catalog of the fields accepted by the selectors of
+// AdGroupFeedService.Get, see NewSelector.
+var AdGroupFeedFields = FieldCatalog{
+	Service: "AdGroupFeedService",
+	Selectable: []string{
+		"AdGroupId", "BaseAdGroupId", "BaseCampaignId", "FeedId",
+		"MatchingFunction", "PlaceholderTypes", "Status",
+	},
+	Filterable: []string{
+		"AdGroupId", "BaseAdGroupId", "BaseCampaignId", "FeedId",
+		"PlaceholderTypes", "Status",
+	},
+	Complete: true,
 }
 
-// Get is not yet implemented
+// Get returns an array of AdGroupFeed's and the total number of AdGroupFeed's
+// matching the selector.
+//
+// Example
+//
+//   adGroupFeeds, totalCount, err := adGroupFeedService.Get(
+//     Selector{
+//       Fields: []string{"AdGroupId","FeedId","MatchingFunction","PlaceholderTypes"},
+//       Predicates: []Predicate{
+//         {"AdGroupId", "IN", []string{"1234","5678"}},
+//       },
+//     },
+//   )
+//
+// Selectable fields are
+//   "AdGroupId", "BaseAdGroupId", "BaseCampaignId", "FeedId",
+//   "MatchingFunction", "PlaceholderTypes", "Status"
+//
+// filterable fields are
+//   "AdGroupId", "BaseAdGroupId", "BaseCampaignId", "FeedId",
+//   "PlaceholderTypes", "Status"
 //
 // Relevant documentation
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupFeedService#get
 //
-func (s AdGroupFeedService) Get(selector Selector) (adGroupFeeds []AdGroupFeed, err error) {
-	return adGroupFeeds, ERROR_NOT_YET_IMPLEMENTED
+func (s *AdGroupFeedService) Get(selector Selector) (adGroupFeeds []AdGroupFeed, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
 }
 
-// Mutate is not yet implemented
+// GetContext is like Get but binds the request to ctx.
+func (s *AdGroupFeedService) GetContext(ctx context.Context, selector Selector) (adGroupFeeds []AdGroupFeed, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		adGroupFeedServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return adGroupFeeds, totalCount, err
+	}
+	getResp := struct {
+		Size         int64         `xml:"rval>totalNumEntries"`
+		AdGroupFeeds []AdGroupFeed `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return adGroupFeeds, totalCount, err
+	}
+	return getResp.AdGroupFeeds, getResp.Size, err
+}
+
+// Mutate allows you to add and remove ad group feeds, returning the
+// modified ad group feeds.
+//
+// Example
+//
+//  adGroupFeeds, err := adGroupFeedService.Mutate(
+//    AdGroupFeedOperations{
+//      "ADD": {
+//        AdGroupFeed{
+//          FeedId:           1234,
+//          AdGroupId:        5678,
+//          MatchingFunction: NewAndFunction(
+//            NewFeedItemIdFunction(1000001),
+//            NewDevicePlatformFunction("Mobile"),
+//          ),
+//          PlaceholderTypes: []int64{PlaceholderTypeCallout},
+//        },
+//      },
+//    },
+//  )
 //
 // Relevant documentation
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupFeedService#mutate
 //
 func (s *AdGroupFeedService) Mutate(adGroupFeedOperations AdGroupFeedOperations) (adGroupFeeds []AdGroupFeed, err error) {
-	return adGroupFeeds, ERROR_NOT_YET_IMPLEMENTED
+	return s.MutateContext(context.Background(), adGroupFeedOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *AdGroupFeedService) MutateContext(ctx context.Context, adGroupFeedOperations AdGroupFeedOperations) (adGroupFeeds []AdGroupFeed, err error) {
+	type adGroupFeedOperation struct {
+		Action      string      `xml:"operator"`
+		AdGroupFeed AdGroupFeed `xml:"operand"`
+	}
+	operations := []adGroupFeedOperation{}
+	for action, adGroupFeeds := range adGroupFeedOperations {
+		for _, adGroupFeed := range adGroupFeeds {
+			operations = append(operations,
+				adGroupFeedOperation{
+					Action:      action,
+					AdGroupFeed: adGroupFeed,
+				},
+			)
+		}
+	}
+	mutation := struct {
+		XMLName xml.Name
+		Ops     []adGroupFeedOperation `xml:"operations"`
+	}{
+		XMLName: xml.Name{
+			Space: baseUrl,
+			Local: "mutate",
+		},
+		Ops: operations,
+	}
+	respBody, err := s.Auth.request(ctx, adGroupFeedServiceUrl, "mutate", mutation)
+	if err != nil {
+		return adGroupFeeds, err
+	}
+	mutateResp := struct {
+		BaseResponse
+		AdGroupFeeds []AdGroupFeed `xml:"rval>value"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &mutateResp)
+	if err != nil {
+		return adGroupFeeds, err
+	}
+
+	if len(mutateResp.PartialFailureErrors) > 0 {
+		err = mutateResp.PartialFailureErrors
+	}
+
+	return mutateResp.AdGroupFeeds, err
 }
 
-// Query is not yet implemented
+// Query returns the ad group feeds matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   adGroupFeeds, totalCount, err := adGroupFeedService.Query("SELECT AdGroupId, FeedId, MatchingFunction WHERE FeedId = 1234")
 //
 // Relevant documentation
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdGroupFeedService#query
 //
-func (s *AdGroupFeedService) Query(query string) (adGroupFeeds []AdGroupFeed, err error) {
-	return adGroupFeeds, ERROR_NOT_YET_IMPLEMENTED
+func (s *AdGroupFeedService) Query(query string) (adGroupFeeds []AdGroupFeed, totalCount int64, err error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *AdGroupFeedService) QueryContext(ctx context.Context, query string) (adGroupFeeds []AdGroupFeed, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		adGroupFeedServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return adGroupFeeds, totalCount, err
+	}
+	queryResp := struct {
+		Size         int64         `xml:"rval>totalNumEntries"`
+		AdGroupFeeds []AdGroupFeed `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return adGroupFeeds, totalCount, err
+	}
+	return queryResp.AdGroupFeeds, queryResp.Size, err
 }
@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 //	"encoding/xml"
 //	"fmt"
 )
@@ -23,5 +24,10 @@ func NewAdParamService(auth *Auth) *AdParamService {
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdParamService#get
 //
 func (s AdParamService) Get(selector Selector) (adParams []AdParam, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s AdParamService) GetContext(ctx context.Context, selector Selector) (adParams []AdParam, err error) {
 	return adParams, ERROR_NOT_YET_IMPLEMENTED
 }
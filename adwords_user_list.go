@@ -1,6 +1,9 @@
 package gads
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 type AdwordsUserListService struct {
 	Auth
@@ -148,6 +151,30 @@ func NewSimilarUserList(name, description, status, integrationCode string, membe
 	}
 }
 
+// AdwordsUserListFields is the catalog of the fields accepted by the selectors of
+// AdwordsUserListService.Get, see NewSelector.
+var AdwordsUserListFields = FieldCatalog{
+	Service: "AdwordsUserListService",
+	Selectable: []string{
+		"Id", "IsReadOnly", "Name", "Description", "Status", "IntegrationCode", "AccessReason",
+		"AccountUserListStatus", "MembershipLifeSpan", "Size", "SizeRange", "SizeForSearch",
+		"SizeRangeForSearch", "ListType",
+		// BasicUserList
+		"ConversionType",
+		// LogicalUserList
+		"Rules",
+		// SimilarUserList
+		"SeedUserListId", "SeedUserListName", "SeedUserListDescription", "SeedUserListStatus",
+		"SeedListSize",
+	},
+	Filterable: []string{
+		"Id", "Name", "Status", "IntegrationCode", "AccessReason", "AccountUserListStatus",
+		"MembershipLifeSpan", "Size", "SizeForSearch", "ListType",
+		// SimilarUserList
+		"SeedUserListId", "SeedListSize",
+	},
+}
+
 // Get returns an array of adwords user lists and the total number of adwords user lists matching
 // the selector.
 //
@@ -194,8 +221,14 @@ func NewSimilarUserList(name, description, status, integrationCode string, membe
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdwordsUserListService#get
 //
 func (s AdwordsUserListService) Get(selector Selector) (userLists []UserList, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s AdwordsUserListService) GetContext(ctx context.Context, selector Selector) (userLists []UserList, err error) {
 	selector.XMLName = xml.Name{"", "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		adwordsUserListServiceUrl,
 		"get",
 		struct {
@@ -231,6 +264,11 @@ func (s AdwordsUserListService) Get(selector Selector) (userLists []UserList, er
 //     https://developers.google.com/adwords/api/docs/reference/v201809/AdwordsUserListService#mutate
 //
 func (s *AdwordsUserListService) Mutate(userListOperations UserListOperations) (adwordsUserLists []UserList, err error) {
+	return s.MutateContext(context.Background(), userListOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *AdwordsUserListService) MutateContext(ctx context.Context, userListOperations UserListOperations) (adwordsUserLists []UserList, err error) {
 	type userListOperation struct {
 		Action   string   `xml:"https://adwords.google.com/api/adwords/cm/v201809 operator"`
 		UserList UserList `xml:"operand"`
@@ -257,7 +295,7 @@ func (s *AdwordsUserListService) Mutate(userListOperations UserListOperations) (
 		},
 		Ops: operations,
 	}
-	respBody, err := s.Auth.request(adwordsUserListServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, adwordsUserListServiceUrl, "mutate", mutation)
 	if err != nil {
 		return
 	}
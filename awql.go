@@ -1,14 +1,10 @@
 package gads
 
 import (
+	"context"
 	"encoding/xml"
-	"fmt"
 	"io"
-	"io/ioutil"
-	"net/http"
 	"net/url"
-	"strconv"
-	"strings"
 )
 
 // AWQLClient struct for AWQL caller
@@ -17,22 +13,24 @@ type AWQLClient struct {
 }
 
 // AWQLFormat type for AWQL format
-type AWQLFormat string
+//
+// Deprecated: AWQL downloads share DownloadFormat with report definitions.
+type AWQLFormat = DownloadFormat
 
-// AWQL formats
+// AWQL formats, kept for compatibility with the DownloadFormat constants
 const (
-	AWQLFormatCSVForExcel AWQLFormat = "CSVFOREXCEL"
-	AWQLFormatCSV         AWQLFormat = "CSV"
-	AWQLFormatTSV         AWQLFormat = "TSV"
-	AWQLFormatXML         AWQLFormat = "XML"
-	AWQLFormatGzippedCSV  AWQLFormat = "GZIPPED_CSV"
-	AWQLFormatGzippedXML  AWQLFormat = "GZIPPED_XML"
+	AWQLFormatCSVForExcel = DownloadFormatCSVForExcel
+	AWQLFormatCSV         = DownloadFormatCSV
+	AWQLFormatTSV         = DownloadFormatTSV
+	AWQLFormatXML         = DownloadFormatXML
+	AWQLFormatGzippedCSV  = DownloadFormatCSVGzipped
+	AWQLFormatGzippedXML  = DownloadFormatXMLGzipped
 )
 
 // AWQLRequest struct for awql request
 type AWQLRequest struct {
 	Query                  string
-	Format                 AWQLFormat
+	Format                 DownloadFormat
 	SkipReportHeader       bool
 	SkipColumnHeader       bool
 	SkipReportSummary      bool
@@ -40,11 +38,17 @@ type AWQLRequest struct {
 	UseRawEnumValues       bool
 }
 
+// AwqlError is the body of a failed download.
+//
+// Deprecated: failed downloads return a *ReportError.
 type AwqlError struct {
 	XMLName xml.Name     `xml:"reportDownloadError"`
 	Error   AwqlApiError `xml:"ApiError"`
 }
 
+// AwqlApiError is the api error of an AwqlError.
+//
+// Deprecated: failed downloads return a *ReportError.
 type AwqlApiError struct {
 	Type      string `xml:"type"`
 	Trigger   string `xml:"trigger"`
@@ -60,50 +64,36 @@ const (
 	maxSizeForNotValidBody = 30
 )
 
-// Download downloads a report by awql request
+// Download downloads a report by awql request, NewReportDecoder reads its rows into structs.
+// A download refused by the api returns a *ReportError.
 func (a *AWQLClient) Download(awqlReq AWQLRequest) (io.ReadCloser, error) {
-	req, err := http.NewRequest(
-		"POST",
-		reportAPIURL,
-		strings.NewReader(url.Values{"__rdquery": {awqlReq.Query}, "__fmt": {string(awqlReq.Format)}}.Encode()),
-	)
-	if err != nil {
+	return a.DownloadContext(context.Background(), awqlReq)
+}
+
+// DownloadContext is like Download but binds the request to ctx. The context
+// also covers the reading of the returned body.
+func (a *AWQLClient) DownloadContext(ctx context.Context, awqlReq AWQLRequest) (io.ReadCloser, error) {
+	if err := awqlReq.Format.Valid(); err != nil {
 		return nil, err
 	}
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded; param=value")
-	req.Header.Add("Accept", "*/*")
-	req.Header.Add("developerToken", a.DeveloperToken)
-	req.Header.Add("clientCustomerId", a.CustomerId)
-	req.Header.Add("skipReportHeader", strconv.FormatBool(awqlReq.SkipReportHeader))
-	req.Header.Add("skipColumnHeader", strconv.FormatBool(awqlReq.SkipColumnHeader))
-	req.Header.Add("skipReportSummary", strconv.FormatBool(awqlReq.SkipReportSummary))
-	req.Header.Add("includeZeroImpressions", strconv.FormatBool(awqlReq.IncludeZeroImpressions))
-	req.Header.Add("useRawEnumValues", strconv.FormatBool(awqlReq.UseRawEnumValues))
-	resp, err := a.Client.Do(req)
+	req, err := a.Auth.newReportRequest(
+		a.CustomerId,
+		url.Values{"__rdquery": {awqlReq.Query}, "__fmt": {string(awqlReq.Format)}},
+		reportHeaders{
+			SkipReportHeader:       awqlReq.SkipReportHeader,
+			SkipColumnHeader:       awqlReq.SkipColumnHeader,
+			SkipReportSummary:      awqlReq.SkipReportSummary,
+			IncludeZeroImpressions: awqlReq.IncludeZeroImpressions,
+			UseRawEnumValues:       awqlReq.UseRawEnumValues,
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != 200 {
-		respBody, errRead := ioutil.ReadAll(resp.Body)
-		resp.Body.Close()
-
-		if errRead != nil {
-			return nil, fmt.Errorf("unexpected StatusCode [%d]", resp.StatusCode)
-		}
-
-		v := AwqlError{}
-		errRead = xml.Unmarshal(respBody, &v)
-		if errRead != nil {
-			bl := len(respBody)
-			if bl > maxSizeForNotValidBody {
-				bl = maxSizeForNotValidBody
-			}
-			return nil, fmt.Errorf("unexpected StatusCode [%d] with content [%s]", resp.StatusCode, string(respBody[:bl]))
-		}
-
-		return nil, fmt.Errorf("[%s @ ; trigger:%s]", v.Error.Type, v.Error.Trigger)
+	call := &ReportCall{
+		CustomerId: a.CustomerId,
+		Query:      awqlReq.Query,
+		Format:     string(awqlReq.Format),
 	}
-
-	return resp.Body, nil
+	return a.Auth.sendReport(ctx, call, req, a.Client)
 }
@@ -0,0 +1,539 @@
+package gads
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Predefined date ranges of the AWQL DURING clause
+const (
+	DateRangeToday            = "TODAY"
+	DateRangeYesterday        = "YESTERDAY"
+	DateRangeLast7Days        = "LAST_7_DAYS"
+	DateRangeLast14Days       = "LAST_14_DAYS"
+	DateRangeLast30Days       = "LAST_30_DAYS"
+	DateRangeLastWeek         = "LAST_WEEK"
+	DateRangeLastBusinessWeek = "LAST_BUSINESS_WEEK"
+	DateRangeLastWeekSunSat   = "LAST_WEEK_SUN_SAT"
+	DateRangeThisWeekSunToday = "THIS_WEEK_SUN_TODAY"
+	DateRangeThisWeekMonToday = "THIS_WEEK_MON_TODAY"
+	DateRangeThisMonth        = "THIS_MONTH"
+	DateRangeLastMonth        = "LAST_MONTH"
+	DateRangeAllTime          = "ALL_TIME"
+)
+
+var awqlDateRanges = []string{
+	DateRangeToday, DateRangeYesterday, DateRangeLast7Days, DateRangeLast14Days,
+	DateRangeLast30Days, DateRangeLastWeek, DateRangeLastBusinessWeek, DateRangeLastWeekSunSat,
+	DateRangeThisWeekSunToday, DateRangeThisWeekMonToday, DateRangeThisMonth, DateRangeLastMonth,
+	DateRangeAllTime,
+}
+
+// awqlOperators maps the operators written as symbols in AWQL, the others
+// are written as their name.
+var awqlOperators = map[Operator]string{
+	OperatorEquals:            "=",
+	OperatorNotEquals:         "!=",
+	OperatorGreaterThan:       ">",
+	OperatorGreaterThanEquals: ">=",
+	OperatorLessThan:          "<",
+	OperatorLessThanEquals:    "<=",
+}
+
+const awqlDateFormat = "20060102"
+
+// AWQLStatement is an AWQL query split in its clauses. Its Selector holds
+// the fields, predicates, date range, ordering and paging, so the same
+// statement can be sent to a service Get or, written with String, to a
+// service Query or a report download.
+type AWQLStatement struct {
+	Selector
+	// From is the report type, service queries have none.
+	From string
+	// DateRangeLiteral is a predefined DURING range such as LAST_7_DAYS,
+	// used instead of Selector.DateRange.
+	DateRangeLiteral string
+}
+
+// Validate checks the operators, the values, the ordering, the paging and
+// the date range of the statement.
+func (st AWQLStatement) Validate() error {
+	errs := []error{(*FieldCatalog)(nil).Validate(st.Selector)}
+	if st.DateRangeLiteral != "" {
+		if st.DateRange != nil {
+			errs = append(errs, errors.New("gads: both a date range and a date range literal"))
+		}
+		if !containsField(awqlDateRanges, st.DateRangeLiteral) {
+			errs = append(errs, fmt.Errorf("gads: unknown date range %q", st.DateRangeLiteral))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// String writes the statement in AWQL, string values are quoted and
+// escaped.
+func (st AWQLStatement) String() string {
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	b.WriteString(strings.Join(st.Fields, ", "))
+	if st.From != "" {
+		b.WriteString(" FROM ")
+		b.WriteString(st.From)
+	}
+	for i, p := range st.Predicates {
+		if i == 0 {
+			b.WriteString(" WHERE ")
+		} else {
+			b.WriteString(" AND ")
+		}
+		op := Operator(p.Operator)
+		symbol, ok := awqlOperators[op]
+		if !ok {
+			symbol = string(op)
+		}
+		fmt.Fprintf(&b, "%s %s ", p.Field, symbol)
+		if op.multiValued() {
+			quoted := make([]string, len(p.Values))
+			for j, v := range p.Values {
+				quoted[j] = quoteAWQL(v)
+			}
+			fmt.Fprintf(&b, "[%s]", strings.Join(quoted, ", "))
+		} else if len(p.Values) > 0 {
+			b.WriteString(quoteAWQL(p.Values[0]))
+		}
+	}
+	switch {
+	case st.DateRangeLiteral != "":
+		b.WriteString(" DURING ")
+		b.WriteString(st.DateRangeLiteral)
+	case st.DateRange != nil:
+		fmt.Fprintf(&b, " DURING %s,%s",
+			time.Time(st.DateRange.Min).Format(awqlDateFormat),
+			time.Time(st.DateRange.Max).Format(awqlDateFormat),
+		)
+	}
+	for i, o := range st.Ordering {
+		if i == 0 {
+			b.WriteString(" ORDER BY ")
+		} else {
+			b.WriteString(", ")
+		}
+		b.WriteString(o.Field)
+		if SortOrder(o.SortOrder) == SortOrderDescending {
+			b.WriteString(" DESC")
+		}
+	}
+	if st.Paging != nil {
+		fmt.Fprintf(&b, " LIMIT %d,%d", st.Paging.Offset, st.Paging.Limit)
+	}
+	return b.String()
+}
+
+// quoteAWQL quotes a string literal, escaping backslashes and quotes.
+func quoteAWQL(v string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
+
+// AWQLBuilder builds AWQL queries.
+//
+// Example
+//
+//   query, err := gads.Select("CampaignId", "Clicks", "Cost").
+//     From("CAMPAIGN_PERFORMANCE_REPORT").
+//     Where("CampaignStatus", gads.OperatorIn, "ENABLED", "PAUSED").
+//     DuringRange(gads.DateRangeLast7Days).
+//     Build()
+//
+type AWQLBuilder struct {
+	st AWQLStatement
+}
+
+// Select starts a query selecting fields.
+func Select(fields ...string) *AWQLBuilder {
+	b := &AWQLBuilder{}
+	b.st.Fields = fields
+	return b
+}
+
+// From sets the report type of the query.
+func (b *AWQLBuilder) From(reportType string) *AWQLBuilder {
+	b.st.From = reportType
+	return b
+}
+
+// Where adds a condition, all the conditions must match.
+func (b *AWQLBuilder) Where(field string, op Operator, values ...string) *AWQLBuilder {
+	b.st.Predicates = append(b.st.Predicates, Predicate{
+		Field:    field,
+		Operator: string(op),
+		Values:   values,
+	})
+	return b
+}
+
+// During restricts the stats to the days between min and max included.
+func (b *AWQLBuilder) During(min, max time.Time) *AWQLBuilder {
+	b.st.DateRange = &DateRange{Min: Date(min), Max: Date(max)}
+	return b
+}
+
+// DuringRange restricts the stats to a predefined date range.
+func (b *AWQLBuilder) DuringRange(dateRange string) *AWQLBuilder {
+	b.st.DateRangeLiteral = dateRange
+	return b
+}
+
+// OrderBy adds an ordering, the first one added takes precedence.
+func (b *AWQLBuilder) OrderBy(field string, order SortOrder) *AWQLBuilder {
+	b.st.Ordering = append(b.st.Ordering, OrderBy{
+		Field:     field,
+		SortOrder: string(order),
+	})
+	return b
+}
+
+// Limit sets the paging of the query.
+func (b *AWQLBuilder) Limit(offset, count int64) *AWQLBuilder {
+	b.st.Paging = &Paging{Offset: offset, Limit: count}
+	return b
+}
+
+// Statement validates and returns the statement built.
+func (b *AWQLBuilder) Statement() (AWQLStatement, error) {
+	if err := b.st.Validate(); err != nil {
+		return AWQLStatement{}, err
+	}
+	return b.st, nil
+}
+
+// Build validates and writes the query.
+func (b *AWQLBuilder) Build() (string, error) {
+	st, err := b.Statement()
+	if err != nil {
+		return "", err
+	}
+	return st.String(), nil
+}
+
+// ParseAWQL parses an AWQL query.
+//
+//   st, err := gads.ParseAWQL("SELECT Id, Name WHERE Status = 'ENABLED' ORDER BY Name LIMIT 0,100")
+//   campaigns, totalCount, err := campaignService.Get(st.Selector)
+//
+func ParseAWQL(query string) (st AWQLStatement, err error) {
+	p := &awqlParser{}
+	if p.tokens, err = lexAWQL(query); err != nil {
+		return st, err
+	}
+	if err = p.statement(&st); err != nil {
+		return AWQLStatement{}, err
+	}
+	if err = st.Validate(); err != nil {
+		return AWQLStatement{}, err
+	}
+	return st, nil
+}
+
+type awqlTokenKind int
+
+const (
+	awqlIdent awqlTokenKind = iota
+	awqlString
+	awqlNumber
+	awqlSymbol
+)
+
+type awqlToken struct {
+	kind  awqlTokenKind
+	value string
+	pos   int
+}
+
+func lexAWQL(query string) (tokens []awqlToken, err error) {
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"':
+			start := i
+			var v strings.Builder
+			for i++; ; i++ {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("gads: unterminated string at %d", start)
+				}
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					v.WriteRune(runes[i])
+					continue
+				}
+				if runes[i] == r {
+					i++
+					break
+				}
+				v.WriteRune(runes[i])
+			}
+			tokens = append(tokens, awqlToken{awqlString, v.String(), start})
+		case r == '-' || unicode.IsDigit(r):
+			start := i
+			for i++; i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.'); i++ {
+			}
+			tokens = append(tokens, awqlToken{awqlNumber, string(runes[start:i]), start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for ; i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.'); i++ {
+			}
+			tokens = append(tokens, awqlToken{awqlIdent, string(runes[start:i]), start})
+		case strings.ContainsRune("!<>=", r):
+			start := i
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				i++
+			}
+			tokens = append(tokens, awqlToken{awqlSymbol, string(runes[start:i]), start})
+		case strings.ContainsRune(",[]", r):
+			tokens = append(tokens, awqlToken{awqlSymbol, string(r), i})
+			i++
+		default:
+			return nil, fmt.Errorf("gads: unexpected %q at %d", r, i)
+		}
+	}
+	return tokens, nil
+}
+
+type awqlParser struct {
+	tokens []awqlToken
+	i      int
+}
+
+func (p *awqlParser) peek() (awqlToken, bool) {
+	if p.i >= len(p.tokens) {
+		return awqlToken{}, false
+	}
+	return p.tokens[p.i], true
+}
+
+func (p *awqlParser) next() (awqlToken, error) {
+	t, ok := p.peek()
+	if !ok {
+		return t, errors.New("gads: unexpected end of query")
+	}
+	p.i++
+	return t, nil
+}
+
+// keyword consumes the next token if it is the keyword kw.
+func (p *awqlParser) keyword(kw string) bool {
+	t, ok := p.peek()
+	if ok && t.kind == awqlIdent && strings.EqualFold(t.value, kw) {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *awqlParser) symbol(s string) bool {
+	t, ok := p.peek()
+	if ok && t.kind == awqlSymbol && t.value == s {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *awqlParser) ident() (string, error) {
+	t, err := p.next()
+	if err != nil {
+		return "", err
+	}
+	if t.kind != awqlIdent {
+		return "", fmt.Errorf("gads: expected a name at %d, got %q", t.pos, t.value)
+	}
+	return t.value, nil
+}
+
+func (p *awqlParser) number() (int64, error) {
+	t, err := p.next()
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(t.value, 10, 64)
+	if t.kind != awqlNumber || err != nil {
+		return 0, fmt.Errorf("gads: expected an integer at %d, got %q", t.pos, t.value)
+	}
+	return n, nil
+}
+
+func (p *awqlParser) date() (Date, error) {
+	t, err := p.next()
+	if err != nil {
+		return Date{}, err
+	}
+	d, err := time.Parse(awqlDateFormat, t.value)
+	if err != nil {
+		return Date{}, fmt.Errorf("gads: expected a yyyyMMdd date at %d, got %q", t.pos, t.value)
+	}
+	return Date(d), nil
+}
+
+func (p *awqlParser) statement(st *AWQLStatement) error {
+	if !p.keyword("SELECT") {
+		return errors.New("gads: query should start with SELECT")
+	}
+	for {
+		f, err := p.ident()
+		if err != nil {
+			return err
+		}
+		st.Fields = append(st.Fields, f)
+		if !p.symbol(",") {
+			break
+		}
+	}
+
+	if p.keyword("FROM") {
+		from, err := p.ident()
+		if err != nil {
+			return err
+		}
+		st.From = from
+	}
+
+	if p.keyword("WHERE") {
+		for {
+			pred, err := p.predicate()
+			if err != nil {
+				return err
+			}
+			st.Predicates = append(st.Predicates, pred)
+			if !p.keyword("AND") {
+				break
+			}
+		}
+	}
+
+	if p.keyword("DURING") {
+		t, err := p.next()
+		if err != nil {
+			return err
+		}
+		if t.kind == awqlIdent {
+			st.DateRangeLiteral = strings.ToUpper(t.value)
+		} else {
+			p.i--
+			min, err := p.date()
+			if err != nil {
+				return err
+			}
+			if !p.symbol(",") {
+				return errors.New("gads: DURING expects two dates")
+			}
+			max, err := p.date()
+			if err != nil {
+				return err
+			}
+			st.DateRange = &DateRange{Min: min, Max: max}
+		}
+	}
+
+	if p.keyword("ORDER") {
+		if !p.keyword("BY") {
+			return errors.New("gads: expected BY after ORDER")
+		}
+		for {
+			f, err := p.ident()
+			if err != nil {
+				return err
+			}
+			order := SortOrderAscending
+			if p.keyword("DESC") {
+				order = SortOrderDescending
+			} else {
+				p.keyword("ASC")
+			}
+			st.Ordering = append(st.Ordering, OrderBy{Field: f, SortOrder: string(order)})
+			if !p.symbol(",") {
+				break
+			}
+		}
+	}
+
+	if p.keyword("LIMIT") {
+		offset, err := p.number()
+		if err != nil {
+			return err
+		}
+		if !p.symbol(",") {
+			return errors.New("gads: LIMIT expects an offset and a count")
+		}
+		count, err := p.number()
+		if err != nil {
+			return err
+		}
+		st.Paging = &Paging{Offset: offset, Limit: count}
+	}
+
+	if t, ok := p.peek(); ok {
+		return fmt.Errorf("gads: unexpected %q at %d", t.value, t.pos)
+	}
+	return nil
+}
+
+func (p *awqlParser) predicate() (pred Predicate, err error) {
+	if pred.Field, err = p.ident(); err != nil {
+		return pred, err
+	}
+	t, err := p.next()
+	if err != nil {
+		return pred, err
+	}
+	op := Operator(strings.ToUpper(t.value))
+	if t.kind == awqlSymbol {
+		op = ""
+		for o, symbol := range awqlOperators {
+			if symbol == t.value {
+				op = o
+			}
+		}
+	}
+	if !op.valid() {
+		return pred, fmt.Errorf("gads: unknown operator %q at %d", t.value, t.pos)
+	}
+	pred.Operator = string(op)
+
+	if !op.multiValued() {
+		v, err := p.value()
+		pred.Values = []string{v}
+		return pred, err
+	}
+	if !p.symbol("[") {
+		return pred, fmt.Errorf("gads: %s expects a [list] of values", op)
+	}
+	for !p.symbol("]") {
+		if len(pred.Values) > 0 && !p.symbol(",") {
+			return pred, fmt.Errorf("gads: expected , or ] in the values of %s", pred.Field)
+		}
+		v, err := p.value()
+		if err != nil {
+			return pred, err
+		}
+		pred.Values = append(pred.Values, v)
+	}
+	return pred, nil
+}
+
+func (p *awqlParser) value() (string, error) {
+	t, err := p.next()
+	if err != nil {
+		return "", err
+	}
+	if t.kind == awqlSymbol {
+		return "", fmt.Errorf("gads: expected a value at %d, got %q", t.pos, t.value)
+	}
+	return t.value, nil
+}
@@ -0,0 +1,111 @@
+package gads
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAWQLBuilder(t *testing.T) {
+	query, err := Select("CampaignId", "CampaignName", "Clicks").
+		From("CAMPAIGN_PERFORMANCE_REPORT").
+		Where("CampaignStatus", OperatorIn, "ENABLED", "PAUSED").
+		Where("CampaignName", OperatorStartsWithIgnoreCase, `Brand's "best"`).
+		Where("Clicks", OperatorGreaterThan, "10").
+		During(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)).
+		OrderBy("Clicks", SortOrderDescending).
+		Limit(0, 100).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `SELECT CampaignId, CampaignName, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT` +
+		` WHERE CampaignStatus IN ['ENABLED', 'PAUSED'] AND CampaignName STARTS_WITH_IGNORE_CASE 'Brand\'s "best"'` +
+		` AND Clicks > '10' DURING 20190101,20190131 ORDER BY Clicks DESC LIMIT 0,100`
+	if query != want {
+		t.Errorf("unexpected query\n got %s\nwant %s", query, want)
+	}
+
+	if _, err := Select("Id").Where("Id", Operator("LIKE"), "1").Build(); err == nil {
+		t.Error("expected an error for an unknown operator")
+	}
+	if _, err := Select("Id").DuringRange("LAST_8_DAYS").Build(); err == nil {
+		t.Error("expected an error for an unknown date range")
+	}
+}
+
+func TestParseAWQL(t *testing.T) {
+	st, err := ParseAWQL(`select Id, Name where Status in ["ENABLED", 'PAUSED'] and Name contains_ignore_case 'it\'s' ` +
+		`and Id != 12 during LAST_7_DAYS order by Name, Id desc limit 10,50`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := AWQLStatement{
+		Selector: Selector{
+			Fields: []string{"Id", "Name"},
+			Predicates: []Predicate{
+				{"Status", "IN", []string{"ENABLED", "PAUSED"}},
+				{"Name", "CONTAINS_IGNORE_CASE", []string{"it's"}},
+				{"Id", "NOT_EQUALS", []string{"12"}},
+			},
+			Ordering: []OrderBy{{"Name", "ASCENDING"}, {"Id", "DESCENDING"}},
+			Paging:   &Paging{Offset: 10, Limit: 50},
+		},
+		DateRangeLiteral: "LAST_7_DAYS",
+	}
+	if !reflect.DeepEqual(st, want) {
+		t.Fatalf("unexpected statement\n got %#v\nwant %#v", st, want)
+	}
+
+	// and back
+	again, err := ParseAWQL(st.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again, st) {
+		t.Errorf("round trip changed the statement\n got %#v\nwant %#v", again, st)
+	}
+
+	for _, bad := range []string{
+		"Id, Name",
+		"SELECT Id WHERE Id LIKE 1",
+		"SELECT Id WHERE Status IN 'ENABLED'",
+		"SELECT Id WHERE Name = 'unterminated",
+		"SELECT Id DURING 20190101",
+		"SELECT Id LIMIT 10",
+		"SELECT Id ORDER Id",
+		"SELECT Id trailing",
+	} {
+		if _, err := ParseAWQL(bad); err == nil {
+			t.Errorf("expected an error parsing %q", bad)
+		}
+	}
+}
+
+func TestServiceQuery(t *testing.T) {
+	var sent string
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		sent = string(body)
+		fmt.Fprint(w, strings.Replace(testEmptyGetResponse, "getResponse", "queryResponse", -1))
+	})
+
+	query, err := Select("Id", "Name").Where("Name", OperatorEquals, "a < b").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, totalCount, err := NewCampaignService(&auth).Query(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if totalCount != 0 {
+		t.Errorf("expected no campaign, got %d", totalCount)
+	}
+	if !strings.Contains(sent, "<query>SELECT Id, Name WHERE Name = &#39;a &lt; b&#39;</query>") {
+		t.Errorf("query not sent as expected: %s", sent)
+	}
+}
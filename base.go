@@ -2,13 +2,15 @@ package gads
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"reflect"
-	"testing"
+	"strings"
 	"time"
 )
 
@@ -18,6 +20,7 @@ const (
 	baseUrl            = "https://adwords.google.com/api/adwords/cm/" + apiVersion
 	rmktgBaseUrl       = "https://adwords.google.com/api/adwords/rm/" + apiVersion
 	managedCustomerUrl = "https://adwords.google.com/api/adwords/mcm/" + apiVersion
+	optimizationUrl    = "https://adwords.google.com/api/adwords/o/" + apiVersion
 	reportAPIURL       = "https://adwords.google.com/api/adwords/reportdownload/" + apiVersion
 	// used for developpement, if true all unknown field will raise an error
 	StrictMode = false
@@ -54,6 +57,7 @@ var (
 	adGroupServiceUrl                  = ServiceUrl{baseUrl, "AdGroupService"}
 	adParamServiceUrl                  = ServiceUrl{baseUrl, "AdParamService"}
 	adwordsUserListServiceUrl          = ServiceUrl{rmktgBaseUrl, "AdwordsUserListService"}
+	batchJobServiceUrl                 = ServiceUrl{baseUrl, "BatchJobService"}
 	biddingStrategyServiceUrl          = ServiceUrl{baseUrl, "BiddingStrategyService"}
 	budgetOrderServiceUrl              = ServiceUrl{baseUrl, "BudgetOrderService"}
 	budgetServiceUrl                   = ServiceUrl{baseUrl, "BudgetService"}
@@ -69,6 +73,8 @@ var (
 	customerServiceUrl                 = ServiceUrl{managedCustomerUrl, "CustomerService"}
 	customerSyncServiceUrl             = ServiceUrl{baseUrl, "CustomerSyncService"}
 	dataServiceUrl                     = ServiceUrl{baseUrl, "DataService"}
+	draftAsyncErrorServiceUrl          = ServiceUrl{baseUrl, "DraftAsyncErrorService"}
+	draftServiceUrl                    = ServiceUrl{baseUrl, "DraftService"}
 	experimentServiceUrl               = ServiceUrl{baseUrl, "ExperimentService"}
 	feedItemServiceUrl                 = ServiceUrl{baseUrl, "FeedItemService"}
 	feedMappingServiceUrl              = ServiceUrl{baseUrl, "FeedMappingService"}
@@ -78,13 +84,14 @@ var (
 	locationCriterionServiceUrl        = ServiceUrl{baseUrl, "LocationCriterionService"}
 	managedCustomerServiceUrl          = ServiceUrl{managedCustomerUrl, "ManagedCustomerService"}
 	mediaServiceUrl                    = ServiceUrl{baseUrl, "MediaService"}
-	mutateJobServiceUrl                = ServiceUrl{baseUrl, "Mutate_JOB_Service"}
 	offlineConversionFeedServiceUrl    = ServiceUrl{baseUrl, "OfflineConversionFeedService"}
 	reportDefinitionServiceUrl         = ServiceUrl{baseUrl, "ReportDefinitionService"}
 	sharedCriterionServiceUrl          = ServiceUrl{baseUrl, "SharedCriterionService"}
 	sharedSetServiceUrl                = ServiceUrl{baseUrl, "SharedSetService"}
-	targetingIdeaServiceUrl            = ServiceUrl{baseUrl, "TargetingIdeaService"}
-	trafficEstimatorServiceUrl         = ServiceUrl{baseUrl, "TrafficEstimatorService"}
+	targetingIdeaServiceUrl            = ServiceUrl{optimizationUrl, "TargetingIdeaService"}
+	trafficEstimatorServiceUrl         = ServiceUrl{optimizationUrl, "TrafficEstimatorService"}
+	trialAsyncErrorServiceUrl          = ServiceUrl{baseUrl, "TrialAsyncErrorService"}
+	trialServiceUrl                    = ServiceUrl{baseUrl, "TrialService"}
 )
 
 func (s ServiceUrl) String() string {
@@ -97,8 +104,19 @@ type Auth struct {
 	UserAgent      string
 	ValidateOnly   bool         `json:"-"`
 	PartialFailure bool         `json:"-"`
-	Testing        *testing.T   `json:"-"`
 	Client         *http.Client `json:"-"`
+
+	// Retry enables retrying of transient failures, nil disables it.
+	Retry *RetryPolicy `json:"-"`
+	// Limiter throttles requests on the client side, nil disables it.
+	Limiter *RateLimiter `json:"-"`
+	// OnResponseHeader is called with the header of every soap response,
+	// for instance to keep track of the operations spent.
+	OnResponseHeader func(ResponseHeader) `json:"-"`
+	// Middlewares wrap every soap call, the first one is the outermost.
+	Middlewares []Middleware `json:"-"`
+	// ReportMiddlewares wrap every report download.
+	ReportMiddlewares []ReportMiddleware `json:"-"`
 }
 
 // Date is a google date, a simple type inference with methods
@@ -152,11 +170,21 @@ func selectorError() (err error) {
 }
 
 func (a *Auth) request(
+	ctx context.Context,
 	serviceUrl ServiceUrl,
 	action string,
 	body interface{},
 ) (respBody []byte, err error) {
+	call, err := a.newSoapCall(serviceUrl, action, body)
+	if err != nil {
+		return []byte{}, err
+	}
+	err = a.do(ctx, call)
+	return call.body, err
+}
 
+// newSoapCall builds the soap envelope of a call to serviceUrl.
+func (a *Auth) newSoapCall(serviceUrl ServiceUrl, action string, body interface{}) (*SoapCall, error) {
 	type devToken struct {
 		XMLName xml.Name
 	}
@@ -203,35 +231,73 @@ func (a *Auth) request(
 		"  ",
 	)
 	if err != nil {
-		return []byte{}, err
+		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", serviceUrl.String(), bytes.NewReader(reqBody))
-	req.Header.Add("Accept", "text/xml")
-	req.Header.Add("Accept", "multipart/*")
-	req.Header.Add("Content-Type", "text/xml;charset=UTF-8")
-	contentLength := fmt.Sprintf("%d", len(reqBody))
-	req.Header.Add("Content-length", contentLength)
-	req.Header.Add("SOAPAction", action)
-	if a.Testing != nil {
-		a.Testing.Logf("request ->\n%s\n%#v\n%s\n", req.URL.String(), req.Header, string(reqBody))
+	call := &SoapCall{
+		Service:    serviceUrl,
+		Action:     action,
+		CustomerId: a.CustomerId,
+		Operations: callOperations(body),
+		Request:    reqBody,
+		HTTPHeader: http.Header{
+			"Accept":         {"text/xml", "multipart/*"},
+			"Content-Type":   {"text/xml;charset=UTF-8"},
+			"Content-Length": {fmt.Sprintf("%d", len(reqBody))},
+			"Soapaction":     {action},
+		},
+	}
+	return call, nil
+}
+
+// do runs call through the limiter, the middlewares and the retry policy.
+// A streamed call is not retried once its entries started to be handed
+// out.
+func (a *Auth) do(ctx context.Context, call *SoapCall) (err error) {
+	handler := a.handler()
+	for attempt := 1; ; attempt++ {
+		if err = a.Limiter.WaitN(ctx, a, call.Operations); err != nil {
+			return err
+		}
+		err = handler(ctx, call)
+		a.Limiter.observe(a, err)
+		if err == nil || call.streamed || !a.Retry.shouldRetry(ctx, attempt, err) {
+			return err
+		}
+		if werr := a.Retry.wait(ctx, attempt, err); werr != nil {
+			return errors.Join(err, werr)
+		}
+	}
+}
+
+// send performs the http round trip of a soap call and extracts the body
+// of the response. It is the innermost SoapHandler.
+func (a *Auth) send(ctx context.Context, call *SoapCall) (err error) {
+	call.StatusCode, call.Response, call.ResponseHeader, call.body = 0, nil, ResponseHeader{}, nil
+	call.streamed = false
+
+	req, err := http.NewRequest("POST", call.Service.String(), bytes.NewReader(call.Request))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range call.HTTPHeader {
+		req.Header[k] = append([]string(nil), v...)
 	}
 	resp, err := a.Client.Do(req)
 	if err != nil {
-		return []byte{}, err
+		return err
 	}
 
-	respBody, err = ioutil.ReadAll(resp.Body)
-	if a.Testing != nil {
-		a.Testing.Logf("respBody ->\n%s\n%s\n", string(respBody), resp.Status)
-	}
+	defer resp.Body.Close()
 
-	type soapRespHeader struct {
-		RequestId    string `xml:"requestId"`
-		ServiceName  string `xml:"serviceName"`
-		MethodName   string `xml:"methodName"`
-		Operations   int64  `xml:"operations"`
-		ResponseTime int64  `xml:"responseTime"`
+	call.StatusCode = resp.StatusCode
+	if call.stream != nil {
+		return a.decodeStream(ctx, call, resp)
+	}
+	call.Response, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
 	}
 
 	type soapRespBody struct {
@@ -240,24 +306,32 @@ func (a *Auth) request(
 
 	soapResp := struct {
 		XMLName xml.Name       `xml:"http://schemas.xmlsoap.org/soap/envelope/ Envelope"`
-		Header  soapRespHeader `xml:"Header>RequestHeader"`
+		Header  ResponseHeader `xml:"Header>ResponseHeader"`
 		Body    soapRespBody   `xml:"http://schemas.xmlsoap.org/soap/envelope/ Body"`
 	}{}
 
-	err = xml.Unmarshal([]byte(respBody), &soapResp)
+	err = xml.Unmarshal(call.Response, &soapResp)
 	if err != nil {
-		return respBody, err
+		if resp.StatusCode >= 500 {
+			// gateway and overload errors don't come with a soap envelope
+			return &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+		}
+		return err
 	}
+	call.ResponseHeader = soapResp.Header
+	call.body = soapResp.Body.Response
+	a.handleResponseHeader(ctx, soapResp.Header)
 	if resp.StatusCode == 400 || resp.StatusCode == 401 || resp.StatusCode == 403 || resp.StatusCode == 405 || resp.StatusCode == 500 {
 		fault := Fault{}
 		// fmt.Printf("unknown error ->\n%s\n", string(soapResp.Body.Response))
 		err = xml.Unmarshal(soapResp.Body.Response, &fault)
 		if err != nil {
-			return respBody, err
+			return err
 		}
-		return soapResp.Body.Response, &fault.Errors
+		fault.Errors.Header = soapResp.Header
+		return &fault.Errors
 	}
-	return soapResp.Body.Response, err
+	return nil
 }
 
 // walk through all the fields recursively
@@ -273,6 +347,34 @@ func addXSIType(obj interface{}) interface{} {
 	return copy.Interface()
 }
 
+// cmXSIType returns a copy of v, a struct with a Type field, whose
+// xsi:type is qualified by the cm prefix. The cm types sent to the
+// services of other namespaces need it, the request declaring the prefix.
+func cmXSIType(v interface{}) interface{} {
+	typed := addXSIType(v)
+	if reflect.TypeOf(typed).Kind() != reflect.Struct {
+		return typed
+	}
+	copy := reflect.New(reflect.TypeOf(typed)).Elem()
+	copy.Set(reflect.ValueOf(typed))
+	if f := copy.FieldByName("Type"); f.Kind() == reflect.String && !strings.Contains(f.String(), ":") {
+		f.SetString("cm:" + f.String())
+	}
+	return copy.Interface()
+}
+
+// cmXSITypes qualifies the xsi:type of values like cmXSIType.
+func cmXSITypes[T any](values []T) []T {
+	if values == nil {
+		return nil
+	}
+	qualified := make([]T, len(values))
+	for i, v := range values {
+		qualified[i] = cmXSIType(v).(T)
+	}
+	return qualified
+}
+
 func addXSITypeRecursive(copy, original reflect.Value) {
 	switch original.Kind() {
 	// The first cases handle nested structures recursively
@@ -31,6 +31,18 @@ func testAuthSetup(t *testing.T) Auth {
 	if err != nil {
 		t.Fatal(err)
 	}
-	config.Auth.Testing = t
+	config.Auth.Middlewares = append(config.Auth.Middlewares, testLogMiddleware(t))
 	return config.Auth
 }
+
+// testLogMiddleware dumps the soap calls in the test log
+func testLogMiddleware(t *testing.T) Middleware {
+	return func(next SoapHandler) SoapHandler {
+		return func(ctx context.Context, call *SoapCall) error {
+			t.Logf("request ->\n%s\n%#v\n%s\n", call.Service, call.HTTPHeader, RedactEnvelope(call.Request))
+			err := next(ctx, call)
+			t.Logf("respBody ->\n%s\n%d\n", call.Response, call.StatusCode)
+			return err
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -66,8 +67,14 @@ type BiddingStrategyOperations map[string][]SharedBiddingStrategy
 
 // Get returns budgets matching a given selector and the total count of matching budgets.
 func (s *BiddingStrategyService) Get(selector Selector) ([]SharedBiddingStrategy, int64, error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *BiddingStrategyService) GetContext(ctx context.Context, selector Selector) ([]SharedBiddingStrategy, int64, error) {
 	selector.XMLName = xml.Name{Space: "", Local: "selector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		biddingStrategyServiceUrl,
 		"get",
 		struct {
@@ -97,6 +104,11 @@ func (s *BiddingStrategyService) Get(selector Selector) ([]SharedBiddingStrategy
 
 // Mutate takes a budgetOperations and creates, modifies or destroys the associated budgets.
 func (s *BiddingStrategyService) Mutate(bidOperations BiddingStrategyOperations) ([]SharedBiddingStrategy, error) {
+	return s.MutateContext(context.Background(), bidOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *BiddingStrategyService) MutateContext(ctx context.Context, bidOperations BiddingStrategyOperations) ([]SharedBiddingStrategy, error) {
 	type bidStratOperation struct {
 		Action   string                `xml:"operator"`
 		BidStrat SharedBiddingStrategy `xml:"operand"`
@@ -110,6 +122,7 @@ func (s *BiddingStrategyService) Mutate(bidOperations BiddingStrategyOperations)
 		}
 	}
 	respBody, err := s.Auth.request(
+		ctx,
 		biddingStrategyServiceUrl,
 		"mutate",
 		struct {
@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 	"encoding/xml"
 	//  "fmt"
 )
@@ -36,10 +37,31 @@ func budgetError() (err error) {
 	return err
 }
 
+// BudgetFields is the catalog of the fields accepted by the selectors of
+// BudgetService.Get, see NewSelector.
+var BudgetFields = FieldCatalog{
+	Service: "BudgetService",
+	Selectable: []string{
+		"BudgetId", "BudgetName", "Amount", "DeliveryMethod", "BudgetReferenceCount",
+		"IsBudgetExplicitlyShared", "BudgetStatus",
+	},
+	Filterable: []string{
+		"BudgetId", "BudgetName", "Amount", "DeliveryMethod", "BudgetReferenceCount",
+		"IsBudgetExplicitlyShared", "BudgetStatus",
+	},
+	Complete: true,
+}
+
 // Get returns budgets matching a given selector and the total count of matching budgets.
 func (s *BudgetService) Get(selector Selector) (budgets []Budget, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *BudgetService) GetContext(ctx context.Context, selector Selector) (budgets []Budget, totalCount int64, err error) {
 	selector.XMLName = xml.Name{"", "selector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		budgetServiceUrl,
 		"get",
 		struct {
@@ -69,6 +91,11 @@ func (s *BudgetService) Get(selector Selector) (budgets []Budget, totalCount int
 
 // Mutate takes a budgetOperations and creates, modifies or destroys the associated budgets.
 func (s *BudgetService) Mutate(budgetOperations BudgetOperations) (budgets []Budget, err error) {
+	return s.MutateContext(context.Background(), budgetOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *BudgetService) MutateContext(ctx context.Context, budgetOperations BudgetOperations) (budgets []Budget, err error) {
 	type budgetOperation struct {
 		Action string `xml:"operator"`
 		Budget Budget `xml:"operand"`
@@ -85,6 +112,7 @@ func (s *BudgetService) Mutate(budgetOperations BudgetOperations) (budgets []Bud
 		}
 	}
 	respBody, err := s.Auth.request(
+		ctx,
 		budgetServiceUrl,
 		"mutate",
 		struct {
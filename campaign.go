@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 )
@@ -276,6 +277,48 @@ type CampaignLabel struct {
 
 type CampaignLabelOperations map[string][]CampaignLabel
 
+// CampaignFields is the catalog of the fields accepted by the selectors of
+// CampaignService.Get, see NewSelector.
+var CampaignFields = FieldCatalog{
+	Service: "CampaignService",
+	Selectable: []string{
+		"Id", "CampaignGroupId", "Name", "Status", "ServingStatus", "StartDate", "EndDate",
+		"AdServingOptimizationStatus", "Settings", "AdvertisingChannelType", "AdvertisingChannelSubType",
+		"Labels", "TrackingUrlTemplate", "FinalUrlSuffix", "UrlCustomParameters", "SelectiveOptimization",
+		"BaseCampaignId", "CampaignTrialType", "Eligible", "RejectionReasons",
+		"VanityPharmaDisplayUrlMode", "VanityPharmaText", "AppId", "AppVendor",
+		"UniversalAppBiddingStrategyGoalType",
+		// Budget
+		"BudgetId", "BudgetName", "Amount", "DeliveryMethod", "BudgetReferenceCount",
+		"IsBudgetExplicitlyShared", "BudgetStatus",
+		// BiddingStrategyConfiguration
+		"BiddingStrategyId", "BiddingStrategyName", "BiddingStrategyType", "BiddingStrategyGoalType",
+		"EnhancedCpcEnabled", "TargetCpa", "TargetCpaMaxCpcBidCeiling", "TargetCpaMaxCpcBidFloor",
+		"TargetRoas", "TargetRoasBidCeiling", "TargetRoasBidFloor", "TargetSpendBidCeiling",
+		"TargetSpendSpendTarget", "MaximizeConversionValueTargetRoas",
+		// FrequencyCap
+		"FrequencyCapMaxImpressions", "Level", "TimeUnit",
+		// NetworkSetting
+		"TargetGoogleSearch", "TargetSearchNetwork", "TargetContentNetwork", "TargetPartnerSearchNetwork",
+	},
+	Filterable: []string{
+		"Id", "CampaignGroupId", "Name", "Status", "ServingStatus", "StartDate", "EndDate",
+		"AdServingOptimizationStatus", "AdvertisingChannelType", "AdvertisingChannelSubType", "Labels",
+		"TrackingUrlTemplate", "FinalUrlSuffix", "BaseCampaignId", "CampaignTrialType", "Eligible",
+		"RejectionReasons", "VanityPharmaDisplayUrlMode", "VanityPharmaText", "AppId", "AppVendor",
+		"UniversalAppBiddingStrategyGoalType",
+		"BudgetId", "BudgetName", "Amount", "DeliveryMethod", "BudgetReferenceCount",
+		"IsBudgetExplicitlyShared", "BudgetStatus",
+		"BiddingStrategyId", "BiddingStrategyName", "BiddingStrategyType", "BiddingStrategyGoalType",
+		"EnhancedCpcEnabled", "TargetCpa", "TargetCpaMaxCpcBidCeiling", "TargetCpaMaxCpcBidFloor",
+		"TargetRoas", "TargetRoasBidCeiling", "TargetRoasBidFloor", "TargetSpendBidCeiling",
+		"TargetSpendSpendTarget", "MaximizeConversionValueTargetRoas",
+		"FrequencyCapMaxImpressions", "Level", "TimeUnit",
+		"TargetGoogleSearch", "TargetSearchNetwork", "TargetContentNetwork", "TargetPartnerSearchNetwork",
+	},
+	Complete: true,
+}
+
 // Get returns an array of Campaign's and the total number of campaign's matching
 // the selector.
 //
@@ -310,8 +353,14 @@ type CampaignLabelOperations map[string][]CampaignLabel
 //     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignService#get
 //
 func (s *CampaignService) Get(selector Selector) (campaigns []Campaign, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *CampaignService) GetContext(ctx context.Context, selector Selector) (campaigns []Campaign, totalCount int64, err error) {
 	selector.XMLName = xml.Name{"", "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		campaignServiceUrl,
 		"get",
 		struct {
@@ -339,6 +388,38 @@ func (s *CampaignService) Get(selector Selector) (campaigns []Campaign, totalCou
 	return getResp.Campaigns, getResp.Size, err
 }
 
+// ForEach streams the campaigns matching the selector to fn, decoding them
+// one at a time from the response instead of holding the whole page in
+// memory. Returning ErrStopStream from fn stops the stream without error,
+// any other error is returned by ForEach.
+//
+// Example
+//
+//   totalCount, err := campaignService.ForEach(ctx, selector, func(campaign Campaign) error {
+//     fmt.Println(campaign.Id)
+//     return nil
+//   })
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignService#get
+//
+func (s *CampaignService) ForEach(ctx context.Context, selector Selector, fn func(Campaign) error) (totalCount int64, err error) {
+	return streamGet[[]Campaign](ctx, &s.Auth, campaignServiceUrl, "get", getSelector(campaignServiceUrl, selector), fn)
+}
+
+// campaignOperand clears the fields of campaign the api refuses in a
+// mutate, so that campaigns returned by Get can be mutated back.
+func campaignOperand(campaign Campaign) Campaign {
+	campaign.CampaignTrialType = nil
+	campaign.AdServingOptimizationStatus = ""
+	// you can't mutate this field too
+	//if campaign.BiddingStrategyConfiguration != nil {
+	//	campaign.BiddingStrategyConfiguration.StrategyType = ""
+	//}
+	return campaign
+}
+
 // Mutate allows you to add and modify campaigns, returning the
 // campaigns.  Note that the "REMOVE" operator is not supported.
 // To remove a campaign set its Status to "REMOVED".
@@ -375,6 +456,11 @@ func (s *CampaignService) Get(selector Selector) (campaigns []Campaign, totalCou
 //     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignService#mutate
 //
 func (s *CampaignService) Mutate(campaignOperations CampaignOperations) (campaigns []Campaign, err error) {
+	return s.MutateContext(context.Background(), campaignOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *CampaignService) MutateContext(ctx context.Context, campaignOperations CampaignOperations) (campaigns []Campaign, err error) {
 	type campaignOperation struct {
 		Action   string   `xml:"operator"`
 		Campaign Campaign `xml:"operand"`
@@ -382,19 +468,10 @@ func (s *CampaignService) Mutate(campaignOperations CampaignOperations) (campaig
 	operations := []campaignOperation{}
 	for action, campaigns := range campaignOperations {
 		for _, campaign := range campaigns {
-			// you can't mutate those fields
-			// if you want to perform campaign mutate from campaign get
-			// you can't.
-			campaign.CampaignTrialType = nil
-			campaign.AdServingOptimizationStatus = ""
-			// you can't mutate this field too
-			//if campaign.BiddingStrategyConfiguration != nil {
-			//	campaign.BiddingStrategyConfiguration.StrategyType = ""
-			//}
 			operations = append(operations,
 				campaignOperation{
 					Action:   action,
-					Campaign: campaign,
+					Campaign: campaignOperand(campaign),
 				},
 			)
 		}
@@ -408,7 +485,7 @@ func (s *CampaignService) Mutate(campaignOperations CampaignOperations) (campaig
 			Local: "mutate",
 		},
 		Ops: operations}
-	respBody, err := s.Auth.request(campaignServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, campaignServiceUrl, "mutate", mutation)
 	if err != nil {
 		return campaigns, err
 	}
@@ -448,6 +525,11 @@ func (s *CampaignService) Mutate(campaignOperations CampaignOperations) (campaig
 //     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignService#mutateLabel
 //
 func (s *CampaignService) MutateLabel(campaignLabelOperations CampaignLabelOperations) (campaignLabels []CampaignLabel, err error) {
+	return s.MutateLabelContext(context.Background(), campaignLabelOperations)
+}
+
+// MutateLabelContext is like MutateLabel but binds the request to ctx.
+func (s *CampaignService) MutateLabelContext(ctx context.Context, campaignLabelOperations CampaignLabelOperations) (campaignLabels []CampaignLabel, err error) {
 	type campaignLabelOperation struct {
 		Action        string        `xml:"operator"`
 		CampaignLabel CampaignLabel `xml:"operand"`
@@ -472,7 +554,7 @@ func (s *CampaignService) MutateLabel(campaignLabelOperations CampaignLabelOpera
 			Local: "mutateLabel",
 		},
 		Ops: operations}
-	respBody, err := s.Auth.request(campaignServiceUrl, "mutateLabel", mutation)
+	respBody, err := s.Auth.request(ctx, campaignServiceUrl, "mutateLabel", mutation)
 	if err != nil {
 		return campaignLabels, err
 	}
@@ -492,12 +574,45 @@ func (s *CampaignService) MutateLabel(campaignLabelOperations CampaignLabelOpera
 	return mutateResp.CampaignLabels, err
 }
 
-// Query is not yet implemented
+// Query returns the campaigns matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   campaigns, totalCount, err := campaignService.Query("SELECT Id, Name WHERE Status IN ['ENABLED', 'PAUSED'] ORDER BY Name LIMIT 0,50")
 //
 // Relevant documentation
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignService#query
 //
 func (s *CampaignService) Query(query string) (campaigns []Campaign, totalCount int64, err error) {
-	return campaigns, totalCount, ERROR_NOT_YET_IMPLEMENTED
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *CampaignService) QueryContext(ctx context.Context, query string) (campaigns []Campaign, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		campaignServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return campaigns, totalCount, err
+	}
+	queryResp := struct {
+		Size      int64      `xml:"rval>totalNumEntries"`
+		Campaigns []Campaign `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return campaigns, totalCount, err
+	}
+	return queryResp.Campaigns, queryResp.Size, err
 }
@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 	//  "strings"
 	//  "strconv"
 	"encoding/xml"
@@ -200,8 +201,14 @@ func NewCampaignCriterionService(auth *Auth) *CampaignCriterionService {
 }
 
 func (s *CampaignCriterionService) Get(selector Selector) (campaignCriterions CampaignCriterions, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *CampaignCriterionService) GetContext(ctx context.Context, selector Selector) (campaignCriterions CampaignCriterions, totalCount int64, err error) {
 	selector.XMLName = xml.Name{"", "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		campaignCriterionServiceUrl,
 		"get",
 		struct {
@@ -230,7 +237,35 @@ func (s *CampaignCriterionService) Get(selector Selector) (campaignCriterions Ca
 	return getResp.CampaignCriterions, getResp.Size, err
 }
 
+// ForEach streams the campaign criterions matching the selector to fn, decoding them
+// one at a time from the response instead of holding the whole page in
+// memory. Returning ErrStopStream from fn stops the stream without error,
+// any other error is returned by ForEach.
+//
+// Example
+//
+//   totalCount, err := campaignCriterionService.ForEach(ctx, selector, func(campaignCriterion interface{}) error {
+//     switch c := campaignCriterion.(type) {
+//     case gads.CampaignCriterion:
+//       fmt.Println(c.CampaignId, c.IsNegative)
+//     }
+//     return nil
+//   })
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignCriterionService#get
+//
+func (s *CampaignCriterionService) ForEach(ctx context.Context, selector Selector, fn func(interface{}) error) (totalCount int64, err error) {
+	return streamGet[CampaignCriterions](ctx, &s.Auth, campaignCriterionServiceUrl, "get", getSelector(campaignCriterionServiceUrl, selector), fn)
+}
+
 func (s *CampaignCriterionService) Mutate(campaignCriterionOperations CampaignCriterionOperations) (campaignCriterions CampaignCriterions, err error) {
+	return s.MutateContext(context.Background(), campaignCriterionOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *CampaignCriterionService) MutateContext(ctx context.Context, campaignCriterionOperations CampaignCriterionOperations) (campaignCriterions CampaignCriterions, err error) {
 	type campaignCriterionOperation struct {
 		Action            string      `xml:"operator"`
 		CampaignCriterion interface{} `xml:"operand"`
@@ -256,7 +291,7 @@ func (s *CampaignCriterionService) Mutate(campaignCriterionOperations CampaignCr
 		},
 		Ops: operations,
 	}
-	respBody, err := s.Auth.request(campaignCriterionServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, campaignCriterionServiceUrl, "mutate", mutation)
 	if err != nil {
 		/*
 			    switch t := err.(type) {
@@ -305,6 +340,45 @@ func (s *CampaignCriterionService) Mutate(campaignCriterionOperations CampaignCr
 	return mutateResp.CampaignCriterions, err
 }
 
+// Query returns the campaign criterions matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   campaignCriterions, err := campaignCriterionService.Query("SELECT Id, CriteriaType WHERE CampaignId = '123'")
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignCriterionService#query
+//
 func (s *CampaignCriterionService) Query(query string) (campaignCriterions CampaignCriterions, err error) {
-	return campaignCriterions, ERROR_NOT_YET_IMPLEMENTED
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *CampaignCriterionService) QueryContext(ctx context.Context, query string) (campaignCriterions CampaignCriterions, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		campaignCriterionServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return campaignCriterions, err
+	}
+	queryResp := struct {
+		Size               int64              `xml:"rval>totalNumEntries"`
+		CampaignCriterions CampaignCriterions `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return campaignCriterions, err
+	}
+	return queryResp.CampaignCriterions, err
 }
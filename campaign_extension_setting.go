@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 )
@@ -98,9 +99,19 @@ func (s *CampaignExtensionSettingService) Get(selector Selector) (
 	extensionSettings []CampaignExtensionSetting,
 	totalCount int64,
 	err error,
+) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *CampaignExtensionSettingService) GetContext(ctx context.Context, selector Selector) (
+	extensionSettings []CampaignExtensionSetting,
+	totalCount int64,
+	err error,
 ) {
 	selector.XMLName = xml.Name{"", "selector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		campaignExtensionSettingServiceUrl,
 		"get",
 		struct {
@@ -136,6 +147,14 @@ func (s *CampaignExtensionSettingService) Get(selector Selector) (
 // see https://developers.google.com/adwords/api/docs/reference/v201809/CampaignExtensionSettingService#mutate
 func (s *CampaignExtensionSettingService) Mutate(
 	campaignExtensionSettingOperations CampaignExtensionSettingOperations,
+) (campaignExtensionSettings []CampaignExtensionSetting, err error) {
+	return s.MutateContext(context.Background(), campaignExtensionSettingOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *CampaignExtensionSettingService) MutateContext(
+	ctx context.Context,
+	campaignExtensionSettingOperations CampaignExtensionSettingOperations,
 ) (campaignExtensionSettings []CampaignExtensionSetting, err error) {
 	type operation struct {
 		Action                   string                   `xml:"operator"`
@@ -164,6 +183,7 @@ func (s *CampaignExtensionSettingService) Mutate(
 		Ops: operations,
 	}
 	respBody, err := s.Auth.request(
+		ctx,
 		campaignExtensionSettingServiceUrl,
 		"mutate",
 		mutation,
@@ -192,9 +212,15 @@ func (s *CampaignExtensionSettingService) Mutate(
 //
 // see https://developers.google.com/adwords/api/docs/reference/v201809/CampaignExtensionSettingService#query
 func (s *CampaignExtensionSettingService) Query(query string) (campaignExtensionSettings []CampaignExtensionSetting, totalCount int64, err error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *CampaignExtensionSettingService) QueryContext(ctx context.Context, query string) (campaignExtensionSettings []CampaignExtensionSetting, totalCount int64, err error) {
 
 	respBody, err := s.Auth.request(
-		adGroupServiceUrl,
+		ctx,
+		campaignExtensionSettingServiceUrl,
 		"query",
 		AWQLQuery{
 			XMLName: xml.Name{
@@ -212,7 +238,6 @@ func (s *CampaignExtensionSettingService) Query(query string) (campaignExtension
 		Size                      int64                      `xml:"rval>totalNumEntries"`
 		CampaignExtensionSettings []CampaignExtensionSetting `xml:"rval>entries"`
 	}{}
-	fmt.Printf("%s\n", respBody)
 	err = xml.Unmarshal([]byte(respBody), &getResp)
 	if err != nil {
 		return campaignExtensionSettings, totalCount, err
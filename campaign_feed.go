@@ -1,5 +1,10 @@
 package gads
 
+import (
+	"context"
+	"encoding/xml"
+)
+
 type CampaignFeedService struct {
 	Auth
 }
@@ -7,3 +12,212 @@ type CampaignFeedService struct {
 func NewCampaignFeedService(auth *Auth) *CampaignFeedService {
 	return &CampaignFeedService{Auth: *auth}
 }
+
+// CampaignFeed attaches a feed to a campaign, the items of the feed
+// matching MatchingFunction serving as the PlaceholderTypes extensions of
+// the campaign.
+type CampaignFeed struct {
+	FeedId           int64    `xml:"feedId"`
+	CampaignId       int64    `xml:"campaignId"`
+	MatchingFunction Function `xml:"matchingFunction"`
+	PlaceholderTypes []int64  `xml:"placeholderTypes"`
+	Status           string   `xml:"status,omitempty"` // Status: "ENABLED", "REMOVED"
+	BaseCampaignId   int64    `xml:"baseCampaignId,omitempty"`
+}
+
+// CampaignFeedOperations is a map of operations to perform on CampaignFeed's
+type CampaignFeedOperations map[string][]CampaignFeed
+
+// CampaignFeedFields is the catalog of the fields accepted by the selectors of
+// CampaignFeedService.Get, see NewSelector.
+var CampaignFeedFields = FieldCatalog{
+	Service: "CampaignFeedService",
+	Selectable: []string{
+		"BaseCampaignId", "CampaignId", "FeedId", "MatchingFunction",
+		"PlaceholderTypes", "Status",
+	},
+	Filterable: []string{
+		"BaseCampaignId", "CampaignId", "FeedId", "PlaceholderTypes", "Status",
+	},
+	Complete: true,
+}
+
+// Get returns an array of CampaignFeed's and the total number of CampaignFeed's
+// matching the selector.
+//
+// Example
+//
+//   campaignFeeds, totalCount, err := campaignFeedService.Get(
+//     Selector{
+//       Fields: []string{"CampaignId","FeedId","MatchingFunction","PlaceholderTypes"},
+//       Predicates: []Predicate{
+//         {"Status", "EQUALS", []string{"ENABLED"}},
+//       },
+//     },
+//   )
+//
+// Selectable fields are
+//   "BaseCampaignId", "CampaignId", "FeedId", "MatchingFunction",
+//   "PlaceholderTypes", "Status"
+//
+// filterable fields are
+//   "BaseCampaignId", "CampaignId", "FeedId", "PlaceholderTypes", "Status"
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignFeedService#get
+//
+func (s *CampaignFeedService) Get(selector Selector) (campaignFeeds []CampaignFeed, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *CampaignFeedService) GetContext(ctx context.Context, selector Selector) (campaignFeeds []CampaignFeed, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		campaignFeedServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return campaignFeeds, totalCount, err
+	}
+	getResp := struct {
+		Size          int64          `xml:"rval>totalNumEntries"`
+		CampaignFeeds []CampaignFeed `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return campaignFeeds, totalCount, err
+	}
+	return getResp.CampaignFeeds, getResp.Size, err
+}
+
+// Mutate allows you to add and remove campaign feeds, returning the
+// modified campaign feeds.
+//
+// Example
+//
+//  campaignFeeds, err := campaignFeedService.Mutate(
+//    CampaignFeedOperations{
+//      "ADD": {
+//        CampaignFeed{
+//          FeedId:           1234,
+//          CampaignId:       5678,
+//          MatchingFunction: NewFeedItemIdFunction(1000001, 1000002),
+//          PlaceholderTypes: []int64{PlaceholderTypeSitelink},
+//        },
+//      },
+//      "REMOVE": {
+//        CampaignFeed{FeedId: 1234, CampaignId: 91011},
+//      },
+//    },
+//  )
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignFeedService#mutate
+//
+func (s *CampaignFeedService) Mutate(campaignFeedOperations CampaignFeedOperations) (campaignFeeds []CampaignFeed, err error) {
+	return s.MutateContext(context.Background(), campaignFeedOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *CampaignFeedService) MutateContext(ctx context.Context, campaignFeedOperations CampaignFeedOperations) (campaignFeeds []CampaignFeed, err error) {
+	type campaignFeedOperation struct {
+		Action       string       `xml:"operator"`
+		CampaignFeed CampaignFeed `xml:"operand"`
+	}
+	operations := []campaignFeedOperation{}
+	for action, campaignFeeds := range campaignFeedOperations {
+		for _, campaignFeed := range campaignFeeds {
+			operations = append(operations,
+				campaignFeedOperation{
+					Action:       action,
+					CampaignFeed: campaignFeed,
+				},
+			)
+		}
+	}
+	mutation := struct {
+		XMLName xml.Name
+		Ops     []campaignFeedOperation `xml:"operations"`
+	}{
+		XMLName: xml.Name{
+			Space: baseUrl,
+			Local: "mutate",
+		},
+		Ops: operations,
+	}
+	respBody, err := s.Auth.request(ctx, campaignFeedServiceUrl, "mutate", mutation)
+	if err != nil {
+		return campaignFeeds, err
+	}
+	mutateResp := struct {
+		BaseResponse
+		CampaignFeeds []CampaignFeed `xml:"rval>value"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &mutateResp)
+	if err != nil {
+		return campaignFeeds, err
+	}
+
+	if len(mutateResp.PartialFailureErrors) > 0 {
+		err = mutateResp.PartialFailureErrors
+	}
+
+	return mutateResp.CampaignFeeds, err
+}
+
+// Query returns the campaign feeds matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   campaignFeeds, totalCount, err := campaignFeedService.Query("SELECT CampaignId, FeedId, MatchingFunction WHERE Status = 'ENABLED'")
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignFeedService#query
+//
+func (s *CampaignFeedService) Query(query string) (campaignFeeds []CampaignFeed, totalCount int64, err error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *CampaignFeedService) QueryContext(ctx context.Context, query string) (campaignFeeds []CampaignFeed, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		campaignFeedServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return campaignFeeds, totalCount, err
+	}
+	queryResp := struct {
+		Size          int64          `xml:"rval>totalNumEntries"`
+		CampaignFeeds []CampaignFeed `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return campaignFeeds, totalCount, err
+	}
+	return queryResp.CampaignFeeds, queryResp.Size, err
+}
@@ -1,5 +1,10 @@
 package gads
 
+import (
+	"context"
+	"encoding/xml"
+)
+
 type CampaignSharedSetService struct {
 	Auth
 }
@@ -7,3 +12,209 @@ type CampaignSharedSetService struct {
 func NewCampaignSharedSetService(auth *Auth) *CampaignSharedSetService {
 	return &CampaignSharedSetService{Auth: *auth}
 }
+
+// CampaignSharedSet attaches a shared set to a campaign, the names, type
+// and status are read only.
+type CampaignSharedSet struct {
+	SharedSetId   int64  `xml:"sharedSetId"`
+	CampaignId    int64  `xml:"campaignId"`
+	SharedSetName string `xml:"sharedSetName,omitempty"`
+	SharedSetType string `xml:"sharedSetType,omitempty"` // SharedSetType: "NEGATIVE_KEYWORDS", "NEGATIVE_PLACEMENTS"
+	CampaignName  string `xml:"campaignName,omitempty"`
+	Status        string `xml:"status,omitempty"` // Status: "ENABLED", "REMOVED"
+}
+
+// CampaignSharedSetOperations is a map of operations to perform on CampaignSharedSet's
+type CampaignSharedSetOperations map[string][]CampaignSharedSet
+
+// CampaignSharedSetFields is the catalog of the fields accepted by the selectors of
+// CampaignSharedSetService.Get, see NewSelector.
+var CampaignSharedSetFields = FieldCatalog{
+	Service: "CampaignSharedSetService",
+	Selectable: []string{
+		"CampaignId", "CampaignName", "SharedSetId", "SharedSetName",
+		"SharedSetType", "Status",
+	},
+	Filterable: []string{
+		"CampaignId", "CampaignName", "SharedSetId", "SharedSetName",
+		"SharedSetType", "Status",
+	},
+	Complete: true,
+}
+
+// Get returns an array of CampaignSharedSet's and the total number of CampaignSharedSet's
+// matching the selector.
+//
+// Example
+//
+//   campaignSharedSets, totalCount, err := campaignSharedSetService.Get(
+//     Selector{
+//       Fields: []string{"CampaignId","SharedSetId","SharedSetName"},
+//       Predicates: []Predicate{
+//         {"SharedSetId", "EQUALS", []string{"1234"}},
+//         {"Status", "EQUALS", []string{"ENABLED"}},
+//       },
+//     },
+//   )
+//
+// Selectable fields are
+//   "CampaignId", "CampaignName", "SharedSetId", "SharedSetName",
+//   "SharedSetType", "Status"
+//
+// filterable fields are
+//   "CampaignId", "CampaignName", "SharedSetId", "SharedSetName",
+//   "SharedSetType", "Status"
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignSharedSetService#get
+//
+func (s *CampaignSharedSetService) Get(selector Selector) (campaignSharedSets []CampaignSharedSet, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *CampaignSharedSetService) GetContext(ctx context.Context, selector Selector) (campaignSharedSets []CampaignSharedSet, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		campaignSharedSetServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return campaignSharedSets, totalCount, err
+	}
+	getResp := struct {
+		Size               int64               `xml:"rval>totalNumEntries"`
+		CampaignSharedSets []CampaignSharedSet `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return campaignSharedSets, totalCount, err
+	}
+	return getResp.CampaignSharedSets, getResp.Size, err
+}
+
+// Mutate allows you to add and remove campaign shared sets, returning the
+// modified campaign shared sets.
+//
+// Example
+//
+//  campaignSharedSets, err := campaignSharedSetService.Mutate(
+//    CampaignSharedSetOperations{
+//      "ADD": {
+//        CampaignSharedSet{SharedSetId: 1234, CampaignId: 5678},
+//      },
+//      "REMOVE": {
+//        CampaignSharedSet{SharedSetId: 1234, CampaignId: 91011},
+//      },
+//    },
+//  )
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignSharedSetService#mutate
+//
+func (s *CampaignSharedSetService) Mutate(campaignSharedSetOperations CampaignSharedSetOperations) (campaignSharedSets []CampaignSharedSet, err error) {
+	return s.MutateContext(context.Background(), campaignSharedSetOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *CampaignSharedSetService) MutateContext(ctx context.Context, campaignSharedSetOperations CampaignSharedSetOperations) (campaignSharedSets []CampaignSharedSet, err error) {
+	type campaignSharedSetOperation struct {
+		Action            string            `xml:"operator"`
+		CampaignSharedSet CampaignSharedSet `xml:"operand"`
+	}
+	operations := []campaignSharedSetOperation{}
+	for action, campaignSharedSets := range campaignSharedSetOperations {
+		for _, campaignSharedSet := range campaignSharedSets {
+			operations = append(operations,
+				campaignSharedSetOperation{
+					Action:            action,
+					CampaignSharedSet: campaignSharedSet,
+				},
+			)
+		}
+	}
+	mutation := struct {
+		XMLName xml.Name
+		Ops     []campaignSharedSetOperation `xml:"operations"`
+	}{
+		XMLName: xml.Name{
+			Space: baseUrl,
+			Local: "mutate",
+		},
+		Ops: operations,
+	}
+	respBody, err := s.Auth.request(ctx, campaignSharedSetServiceUrl, "mutate", mutation)
+	if err != nil {
+		return campaignSharedSets, err
+	}
+	mutateResp := struct {
+		BaseResponse
+		CampaignSharedSets []CampaignSharedSet `xml:"rval>value"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &mutateResp)
+	if err != nil {
+		return campaignSharedSets, err
+	}
+
+	if len(mutateResp.PartialFailureErrors) > 0 {
+		err = mutateResp.PartialFailureErrors
+	}
+
+	return mutateResp.CampaignSharedSets, err
+}
+
+// Query returns the campaign shared sets matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   campaignSharedSets, totalCount, err := campaignSharedSetService.Query("SELECT CampaignId, SharedSetId WHERE SharedSetType = 'NEGATIVE_KEYWORDS'")
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CampaignSharedSetService#query
+//
+func (s *CampaignSharedSetService) Query(query string) (campaignSharedSets []CampaignSharedSet, totalCount int64, err error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *CampaignSharedSetService) QueryContext(ctx context.Context, query string) (campaignSharedSets []CampaignSharedSet, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		campaignSharedSetServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return campaignSharedSets, totalCount, err
+	}
+	queryResp := struct {
+		Size               int64               `xml:"rval>totalNumEntries"`
+		CampaignSharedSets []CampaignSharedSet `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return campaignSharedSets, totalCount, err
+	}
+	return queryResp.CampaignSharedSets, queryResp.Size, err
+}
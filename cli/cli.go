@@ -17,167 +17,112 @@ func main() {
 	}
 	bs := gads.NewBudgetService(&config.Auth)
 
-	var pageSize int64 = 500
-	var offset int64 = 0
-	paging := gads.Paging{
-		Offset: offset,
-		Limit:  pageSize,
-	}
+	ctx := context.Background()
 	fmt.Printf("\nBudgets\n")
-	for {
-		foundBudgets, totalCount, err := bs.Get(gads.Selector{
-			Fields: []string{
-				"BudgetId",
-				"BudgetName",
-				"Amount",
-				"DeliveryMethod",
-				"BudgetReferenceCount",
-				"IsBudgetExplicitlyShared",
-				"BudgetStatus",
-			},
-			Paging: &paging,
-		})
-		if err != nil {
-			log.Fatal(err)
-		}
-		for _, budget := range foundBudgets {
-			budgetJson, _ := json.MarshalIndent(budget, "", "  ")
-			fmt.Printf("  %s\n", string(budgetJson))
-		}
-		offset += pageSize
-		paging.Offset = offset
-		if totalCount < offset {
-			break
-		}
+	budgets := gads.NewPager(ctx, bs.GetContext, gads.Selector{
+		Fields: []string{
+			"BudgetId",
+			"BudgetName",
+			"Amount",
+			"DeliveryMethod",
+			"BudgetReferenceCount",
+			"IsBudgetExplicitlyShared",
+			"BudgetStatus",
+		},
+	})
+	for budgets.Next() {
+		budgetJson, _ := json.MarshalIndent(budgets.Entry(), "", "  ")
+		fmt.Printf("  %s\n", string(budgetJson))
+	}
+	if err := budgets.Err(); err != nil {
+		log.Fatal(err)
 	}
 
 	// show all Campaigns
 	cs := gads.NewCampaignService(&config.Auth)
-	offset = 0
-	paging = gads.Paging{
-		Offset: offset,
-		Limit:  pageSize,
-	}
 	fmt.Printf("\nCampaigns\n")
-	for {
-		foundCampaigns, totalCount, err := cs.Get(
-			gads.Selector{
-				Fields: []string{
-					"Id",
-					"BudgetId",
-					"Name",
-					"Status",
-					"ServingStatus",
-					"StartDate",
-					"EndDate",
-					"AdServingOptimizationStatus",
-					"Settings",
-					"AdvertisingChannelType",
-					"AdvertisingChannelSubType",
-					"Labels",
-					"TrackingUrlTemplate",
-					"UrlCustomParameters",
-				},
-				Predicates: []gads.Predicate{
-					{"Status", "EQUALS", []string{"PAUSED"}},
-				},
-				Ordering: []gads.OrderBy{
-					{"Id", "ASCENDING"},
-				},
-				Paging: &paging,
-			},
-		)
-		if err != nil {
-			log.Fatal(err)
-		}
-		for _, campaign := range foundCampaigns {
-			campaignJson, _ := json.MarshalIndent(campaign, "", "  ")
-			fmt.Printf("%s\n", campaignJson)
-		}
-		offset += pageSize
-		paging.Offset = offset
-		if totalCount < offset {
-			break
-		}
+	campaigns := gads.NewPager(ctx, cs.GetContext, gads.Selector{
+		Fields: []string{
+			"Id",
+			"BudgetId",
+			"Name",
+			"Status",
+			"ServingStatus",
+			"StartDate",
+			"EndDate",
+			"AdServingOptimizationStatus",
+			"Settings",
+			"AdvertisingChannelType",
+			"AdvertisingChannelSubType",
+			"Labels",
+			"TrackingUrlTemplate",
+			"UrlCustomParameters",
+		},
+		Predicates: []gads.Predicate{
+			{"Status", "EQUALS", []string{"PAUSED"}},
+		},
+		Ordering: []gads.OrderBy{
+			{"Id", "ASCENDING"},
+		},
+	})
+	for campaigns.Next() {
+		campaign := campaigns.Entry()
+		campaignJson, _ := json.MarshalIndent(campaign, "", "  ")
+		fmt.Printf("%s\n", campaignJson)
+	}
+	if err := campaigns.Err(); err != nil {
+		log.Fatal(err)
 	}
 
 	ags := gads.NewAdGroupService(&config.Auth)
-	offset = 0
-	paging = gads.Paging{
-		Offset: offset,
-		Limit:  pageSize,
-	}
 	fmt.Printf("\nAdGroups\n")
-	for {
-		foundAdGroups, totalCount, err := ags.Get(
-			gads.Selector{
-				Fields: []string{
-					"Id",
-					"CampaignId",
-					"CampaignName",
-					"Name",
-					"Status",
-					"Settings",
-					"ContentBidCriterionTypeGroup",
-				},
-				Predicates: []gads.Predicate{
-					{"Status", "EQUALS", []string{"PAUSED"}},
-				},
-				Ordering: []gads.OrderBy{
-					{"Id", "ASCENDING"},
-				},
-				Paging: &paging,
-			},
-		)
-		if err != nil {
-			log.Fatal(err)
-		}
-		for _, adGroup := range foundAdGroups {
-			adGroupJson, _ := json.MarshalIndent(adGroup, "", "  ")
-			fmt.Printf("%#v\n", adGroupJson)
-		}
-		offset += pageSize
-		paging.Offset = offset
-		if totalCount < offset {
-			break
-		}
+	adGroups := gads.NewPager(ctx, ags.GetContext, gads.Selector{
+		Fields: []string{
+			"Id",
+			"CampaignId",
+			"CampaignName",
+			"Name",
+			"Status",
+			"Settings",
+			"ContentBidCriterionTypeGroup",
+		},
+		Predicates: []gads.Predicate{
+			{"Status", "EQUALS", []string{"PAUSED"}},
+		},
+		Ordering: []gads.OrderBy{
+			{"Id", "ASCENDING"},
+		},
+	})
+	for adGroups.Next() {
+		adGroup := adGroups.Entry()
+		adGroupJson, _ := json.MarshalIndent(adGroup, "", "  ")
+		fmt.Printf("%#v\n", adGroupJson)
+	}
+	if err := adGroups.Err(); err != nil {
+		log.Fatal(err)
 	}
 
 	agas := gads.NewAdGroupAdService(&config.Auth)
-	offset = 0
-	paging = gads.Paging{
-		Offset: offset,
-		Limit:  pageSize,
-	}
 	fmt.Printf("\nAds\n")
-	for {
-		foundAds, totalCount, err := agas.Get(
-			gads.Selector{
-				Fields: []string{
-					"AdGroupId",
-					"Status",
-					"AdGroupCreativeApprovalStatus",
-					"AdGroupAdDisapprovalReasons",
-					"AdGroupAdTrademarkDisapproved",
-				},
-				Ordering: []gads.OrderBy{
-					{"AdGroupId", "ASCENDING"},
-					{"Id", "ASCENDING"},
-				},
-				Paging: &paging,
-			},
-		)
-		if err != nil {
-			log.Fatal(err)
-		}
-		for _, ad := range foundAds {
-			adJson, _ := json.MarshalIndent(ad, "", "  ")
-			fmt.Printf("%s\n", adJson)
-		}
-		offset += pageSize
-		paging.Offset = offset
-		if totalCount < offset {
-			break
-		}
+	ads := gads.NewPager(ctx, agas.GetContext, gads.Selector{
+		Fields: []string{
+			"AdGroupId",
+			"Status",
+			"AdGroupCreativeApprovalStatus",
+			"AdGroupAdDisapprovalReasons",
+			"AdGroupAdTrademarkDisapproved",
+		},
+		Ordering: []gads.OrderBy{
+			{"AdGroupId", "ASCENDING"},
+			{"Id", "ASCENDING"},
+		},
+	})
+	for ads.Next() {
+		ad := ads.Entry()
+		adJson, _ := json.MarshalIndent(ad, "", "  ")
+		fmt.Printf("%s\n", adJson)
+	}
+	if err := ads.Err(); err != nil {
+		log.Fatal(err)
 	}
 }
@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -31,8 +32,14 @@ type ProductBiddingCategoryData struct {
 }
 
 func (s *ConstantDataService) GetProductBiddingCategoryDatas(selector Selector) (datas []ProductBiddingCategoryData, err error) {
+	return s.GetProductBiddingCategoryDatasContext(context.Background(), selector)
+}
+
+// GetProductBiddingCategoryDatasContext is like GetProductBiddingCategoryDatas but binds the request to ctx.
+func (s *ConstantDataService) GetProductBiddingCategoryDatasContext(ctx context.Context, selector Selector) (datas []ProductBiddingCategoryData, err error) {
 	selector.XMLName = xml.Name{"", "selector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getProductBiddingCategoryData",
 		struct {
@@ -61,7 +68,13 @@ func (s *ConstantDataService) GetProductBiddingCategoryDatas(selector Selector)
 }
 
 func (s *ConstantDataService) GetAgeRangeCriterion() (ageRanges []AgeRangeCriterion, err error) {
+	return s.GetAgeRangeCriterionContext(context.Background())
+}
+
+// GetAgeRangeCriterionContext is like GetAgeRangeCriterion but binds the request to ctx.
+func (s *ConstantDataService) GetAgeRangeCriterionContext(ctx context.Context) (ageRanges []AgeRangeCriterion, err error) {
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getAgeRangeCriterion",
 		struct {
@@ -82,7 +95,13 @@ func (s *ConstantDataService) GetAgeRangeCriterion() (ageRanges []AgeRangeCriter
 }
 
 func (s *ConstantDataService) GetCarrierCriterion() (carriers []CarrierCriterion, err error) {
+	return s.GetCarrierCriterionContext(context.Background())
+}
+
+// GetCarrierCriterionContext is like GetCarrierCriterion but binds the request to ctx.
+func (s *ConstantDataService) GetCarrierCriterionContext(ctx context.Context) (carriers []CarrierCriterion, err error) {
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getCarrierCriterion",
 		struct {
@@ -103,7 +122,13 @@ func (s *ConstantDataService) GetCarrierCriterion() (carriers []CarrierCriterion
 }
 
 func (s *ConstantDataService) GetGenderCriterion() (genders []GenderCriterion, err error) {
+	return s.GetGenderCriterionContext(context.Background())
+}
+
+// GetGenderCriterionContext is like GetGenderCriterion but binds the request to ctx.
+func (s *ConstantDataService) GetGenderCriterionContext(ctx context.Context) (genders []GenderCriterion, err error) {
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getGenderCriterion",
 		struct {
@@ -124,7 +149,13 @@ func (s *ConstantDataService) GetGenderCriterion() (genders []GenderCriterion, e
 }
 
 func (s *ConstantDataService) GetLanguageCriterion() (languages []LanguageCriterion, err error) {
+	return s.GetLanguageCriterionContext(context.Background())
+}
+
+// GetLanguageCriterionContext is like GetLanguageCriterion but binds the request to ctx.
+func (s *ConstantDataService) GetLanguageCriterionContext(ctx context.Context) (languages []LanguageCriterion, err error) {
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getLanguageCriterion",
 		struct {
@@ -145,7 +176,13 @@ func (s *ConstantDataService) GetLanguageCriterion() (languages []LanguageCriter
 }
 
 func (s *ConstantDataService) GetMobileDeviceCriterion() (mobileDevices []MobileDeviceCriterion, err error) {
+	return s.GetMobileDeviceCriterionContext(context.Background())
+}
+
+// GetMobileDeviceCriterionContext is like GetMobileDeviceCriterion but binds the request to ctx.
+func (s *ConstantDataService) GetMobileDeviceCriterionContext(ctx context.Context) (mobileDevices []MobileDeviceCriterion, err error) {
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getMobileDeviceCriterion",
 		struct {
@@ -166,7 +203,13 @@ func (s *ConstantDataService) GetMobileDeviceCriterion() (mobileDevices []Mobile
 }
 
 func (s *ConstantDataService) GetOperatingSystemVersionCriterion() (operatingSystemVersions []OperatingSystemVersionCriterion, err error) {
+	return s.GetOperatingSystemVersionCriterionContext(context.Background())
+}
+
+// GetOperatingSystemVersionCriterionContext is like GetOperatingSystemVersionCriterion but binds the request to ctx.
+func (s *ConstantDataService) GetOperatingSystemVersionCriterionContext(ctx context.Context) (operatingSystemVersions []OperatingSystemVersionCriterion, err error) {
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getOperatingSystemVersionCriterion",
 		struct {
@@ -187,7 +230,13 @@ func (s *ConstantDataService) GetOperatingSystemVersionCriterion() (operatingSys
 }
 
 func (s *ConstantDataService) GetUserInterestCriterion() (userInterests []UserInterestCriterion, err error) {
+	return s.GetUserInterestCriterionContext(context.Background())
+}
+
+// GetUserInterestCriterionContext is like GetUserInterestCriterion but binds the request to ctx.
+func (s *ConstantDataService) GetUserInterestCriterionContext(ctx context.Context) (userInterests []UserInterestCriterion, err error) {
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getUserInterestCriterion",
 		struct {
@@ -208,7 +257,13 @@ func (s *ConstantDataService) GetUserInterestCriterion() (userInterests []UserIn
 }
 
 func (s *ConstantDataService) GetVerticalCriterion() (verticals []VerticalCriterion, err error) {
+	return s.GetVerticalCriterionContext(context.Background())
+}
+
+// GetVerticalCriterionContext is like GetVerticalCriterion but binds the request to ctx.
+func (s *ConstantDataService) GetVerticalCriterionContext(ctx context.Context) (verticals []VerticalCriterion, err error) {
 	respBody, err := s.Auth.request(
+		ctx,
 		constantDataServiceUrl,
 		"getVerticalCriterion",
 		struct {
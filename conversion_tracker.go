@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 )
@@ -96,6 +97,14 @@ func NewConversionTrackerService(auth *Auth) *ConversionTrackerService {
 func (s *ConversionTrackerService) Mutate(
 	conversionTrackerOperations ConversionTrackerOperations,
 ) (conversionTrackers ConversionTrackers, err error) {
+	return s.MutateContext(context.Background(), conversionTrackerOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *ConversionTrackerService) MutateContext(
+	ctx context.Context,
+	conversionTrackerOperations ConversionTrackerOperations,
+) (conversionTrackers ConversionTrackers, err error) {
 
 	//TODO: there should be a way to factorize things so that one
 	// should only have to do a call
@@ -132,7 +141,7 @@ func (s *ConversionTrackerService) Mutate(
 		},
 		Ops: operations,
 	}
-	respBody, err := s.Auth.request(conversionTrackerServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, conversionTrackerServiceUrl, "mutate", mutation)
 	if err != nil {
 		return conversionTrackers, err
 	}
@@ -156,9 +165,19 @@ func (s *ConversionTrackerService) Get(selector Selector) (
 	items ConversionTrackers,
 	totalCount int64,
 	err error,
+) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *ConversionTrackerService) GetContext(ctx context.Context, selector Selector) (
+	items ConversionTrackers,
+	totalCount int64,
+	err error,
 ) {
 	selector.XMLName = xml.Name{"", "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		conversionTrackerServiceUrl,
 		"get",
 		struct {
@@ -1,6 +1,9 @@
 package gads
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 // CustomerService fetches or modify Customer properties
 type CustomerService struct {
@@ -31,6 +34,14 @@ func NewCustomerService(auth *Auth) *CustomerService {
 func (m *CustomerService) GetCustomers(s *Selector) (
 	customers []Customer,
 	err error,
+) {
+	return m.GetCustomersContext(context.Background(), s)
+}
+
+// GetCustomersContext is like GetCustomers but binds the request to ctx.
+func (m *CustomerService) GetCustomersContext(ctx context.Context, s *Selector) (
+	customers []Customer,
+	err error,
 ) {
 	if s != nil {
 		s.XMLName = xml.Name{"", "serviceSelector"}
@@ -38,6 +49,7 @@ func (m *CustomerService) GetCustomers(s *Selector) (
 
 	var respBody []byte
 	respBody, err = m.request(
+		ctx,
 		customerServiceUrl,
 		"getCustomers",
 		struct {
@@ -70,6 +82,11 @@ func (m *CustomerService) GetCustomers(s *Selector) (
 
 // Mutate performs modifications of one or many customer
 func (m *CustomerService) Mutate(c Customer) (customer Customer, err error) {
+	return m.MutateContext(context.Background(), c)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (m *CustomerService) MutateContext(ctx context.Context, c Customer) (customer Customer, err error) {
 
 	mutation := struct {
 		XMLName  xml.Name
@@ -81,7 +98,7 @@ func (m *CustomerService) Mutate(c Customer) (customer Customer, err error) {
 		},
 		Customer: c,
 	}
-	respBody, err := m.request(customerServiceUrl, "mutate", mutation)
+	respBody, err := m.request(ctx, customerServiceUrl, "mutate", mutation)
 	if err != nil {
 		return customer, err
 	}
@@ -113,12 +130,18 @@ type ServiceLink struct {
 
 // GetServiceLinks fetches the service links
 func (m *CustomerService) GetServiceLinks(s *Selector) (serviceLinks []ServiceLink, err error) {
+	return m.GetServiceLinksContext(context.Background(), s)
+}
+
+// GetServiceLinksContext is like GetServiceLinks but binds the request to ctx.
+func (m *CustomerService) GetServiceLinksContext(ctx context.Context, s *Selector) (serviceLinks []ServiceLink, err error) {
 	if s != nil {
 		s.XMLName = xml.Name{"", "selector"}
 	}
 
 	var respBody []byte
 	respBody, err = m.request(
+		ctx,
 		customerServiceUrl,
 		"getServiceLinks",
 		struct {
@@ -152,6 +175,11 @@ func (m *CustomerService) GetServiceLinks(s *Selector) (serviceLinks []ServiceLi
 type ServiceLinkOperations map[string][]ServiceLink
 
 func (s *CustomerService) MutateServiceLinks(ops ServiceLinkOperations) (links []ServiceLink, err error) {
+	return s.MutateServiceLinksContext(context.Background(), ops)
+}
+
+// MutateServiceLinksContext is like MutateServiceLinks but binds the request to ctx.
+func (s *CustomerService) MutateServiceLinksContext(ctx context.Context, ops ServiceLinkOperations) (links []ServiceLink, err error) {
 	type linkOperation struct {
 		Action      string      `xml:"https://adwords.google.com/api/adwords/cm/v201809 operator"`
 		ServiceLink ServiceLink `xml:"operand"`
@@ -163,6 +191,7 @@ func (s *CustomerService) MutateServiceLinks(ops ServiceLinkOperations) (links [
 		}
 	}
 	respBody, err := s.Auth.request(
+		ctx,
 		customerServiceUrl,
 		"mutateServiceLinks",
 		struct {
@@ -1,5 +1,10 @@
 package gads
 
+import (
+	"context"
+	"encoding/xml"
+)
+
 type CustomerFeedService struct {
 	Auth
 }
@@ -7,3 +12,204 @@ type CustomerFeedService struct {
 func NewCustomerFeedService(auth *Auth) *CustomerFeedService {
 	return &CustomerFeedService{Auth: *auth}
 }
+
+// CustomerFeed attaches a feed to the whole account, the items of the
+// feed matching MatchingFunction serving as the PlaceholderTypes
+// extensions of every campaign.
+type CustomerFeed struct {
+	FeedId           int64    `xml:"feedId"`
+	MatchingFunction Function `xml:"matchingFunction"`
+	PlaceholderTypes []int64  `xml:"placeholderTypes"`
+	Status           string   `xml:"status,omitempty"` // Status: "ENABLED", "REMOVED"
+}
+
+// CustomerFeedOperations is a map of operations to perform on CustomerFeed's
+type CustomerFeedOperations map[string][]CustomerFeed
+
+// CustomerFeedFields is the catalog of the fields accepted by the selectors of
+// CustomerFeedService.Get, see NewSelector.
+var CustomerFeedFields = FieldCatalog{
+	Service: "CustomerFeedService",
+	Selectable: []string{
+		"FeedId", "MatchingFunction", "PlaceholderTypes", "Status",
+	},
+	Filterable: []string{
+		"FeedId", "PlaceholderTypes", "Status",
+	},
+	Complete: true,
+}
+
+// Get returns an array of CustomerFeed's and the total number of CustomerFeed's
+// matching the selector.
+//
+// Example
+//
+//   customerFeeds, totalCount, err := customerFeedService.Get(
+//     Selector{
+//       Fields: []string{"FeedId","MatchingFunction","PlaceholderTypes"},
+//       Predicates: []Predicate{
+//         {"Status", "EQUALS", []string{"ENABLED"}},
+//       },
+//     },
+//   )
+//
+// Selectable fields are
+//   "FeedId", "MatchingFunction", "PlaceholderTypes", "Status"
+//
+// filterable fields are
+//   "FeedId", "PlaceholderTypes", "Status"
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CustomerFeedService#get
+//
+func (s *CustomerFeedService) Get(selector Selector) (customerFeeds []CustomerFeed, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *CustomerFeedService) GetContext(ctx context.Context, selector Selector) (customerFeeds []CustomerFeed, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		customerFeedServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return customerFeeds, totalCount, err
+	}
+	getResp := struct {
+		Size          int64          `xml:"rval>totalNumEntries"`
+		CustomerFeeds []CustomerFeed `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return customerFeeds, totalCount, err
+	}
+	return getResp.CustomerFeeds, getResp.Size, err
+}
+
+// Mutate allows you to add and remove customer feeds, returning the
+// modified customer feeds.
+//
+// Example
+//
+//  customerFeeds, err := customerFeedService.Mutate(
+//    CustomerFeedOperations{
+//      "ADD": {
+//        CustomerFeed{
+//          FeedId:           1234,
+//          MatchingFunction: NewIdentityFunction(true),
+//          PlaceholderTypes: []int64{PlaceholderTypeCallout},
+//        },
+//      },
+//    },
+//  )
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CustomerFeedService#mutate
+//
+func (s *CustomerFeedService) Mutate(customerFeedOperations CustomerFeedOperations) (customerFeeds []CustomerFeed, err error) {
+	return s.MutateContext(context.Background(), customerFeedOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *CustomerFeedService) MutateContext(ctx context.Context, customerFeedOperations CustomerFeedOperations) (customerFeeds []CustomerFeed, err error) {
+	type customerFeedOperation struct {
+		Action       string       `xml:"operator"`
+		CustomerFeed CustomerFeed `xml:"operand"`
+	}
+	operations := []customerFeedOperation{}
+	for action, customerFeeds := range customerFeedOperations {
+		for _, customerFeed := range customerFeeds {
+			operations = append(operations,
+				customerFeedOperation{
+					Action:       action,
+					CustomerFeed: customerFeed,
+				},
+			)
+		}
+	}
+	mutation := struct {
+		XMLName xml.Name
+		Ops     []customerFeedOperation `xml:"operations"`
+	}{
+		XMLName: xml.Name{
+			Space: baseUrl,
+			Local: "mutate",
+		},
+		Ops: operations,
+	}
+	respBody, err := s.Auth.request(ctx, customerFeedServiceUrl, "mutate", mutation)
+	if err != nil {
+		return customerFeeds, err
+	}
+	mutateResp := struct {
+		BaseResponse
+		CustomerFeeds []CustomerFeed `xml:"rval>value"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &mutateResp)
+	if err != nil {
+		return customerFeeds, err
+	}
+
+	if len(mutateResp.PartialFailureErrors) > 0 {
+		err = mutateResp.PartialFailureErrors
+	}
+
+	return mutateResp.CustomerFeeds, err
+}
+
+// Query returns the customer feeds matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   customerFeeds, totalCount, err := customerFeedService.Query("SELECT FeedId, MatchingFunction WHERE Status = 'ENABLED'")
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CustomerFeedService#query
+//
+func (s *CustomerFeedService) Query(query string) (customerFeeds []CustomerFeed, totalCount int64, err error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *CustomerFeedService) QueryContext(ctx context.Context, query string) (customerFeeds []CustomerFeed, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		customerFeedServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return customerFeeds, totalCount, err
+	}
+	queryResp := struct {
+		Size          int64          `xml:"rval>totalNumEntries"`
+		CustomerFeeds []CustomerFeed `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return customerFeeds, totalCount, err
+	}
+	return queryResp.CustomerFeeds, queryResp.Size, err
+}
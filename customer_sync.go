@@ -1,5 +1,12 @@
 package gads
 
+import (
+	"context"
+	"encoding/xml"
+	"sync"
+	"time"
+)
+
 type CustomerSyncService struct {
 	Auth
 }
@@ -7,3 +14,288 @@ type CustomerSyncService struct {
 func NewCustomerSyncService(auth *Auth) *CustomerSyncService {
 	return &CustomerSyncService{Auth: *auth}
 }
+
+// Change statuses of the entities reported by CustomerSyncService
+const (
+	ChangeStatusFieldsUnchanged = "FIELDS_UNCHANGED"
+	ChangeStatusFieldsChanged   = "FIELDS_CHANGED"
+	ChangeStatusNew             = "NEW"
+)
+
+// DateTimeRange bounds the changes returned by CustomerSyncService.Get,
+// its bounds are formatted by FormatDateTime or are the
+// LastChangeTimestamp of a previous sync.
+type DateTimeRange struct {
+	Min string `xml:"min,omitempty"`
+	Max string `xml:"max,omitempty"`
+}
+
+// FormatDateTime formats t as a bound of a DateTimeRange.
+func FormatDateTime(t time.Time) string {
+	return t.UTC().Format("20060102 150405") + " UTC"
+}
+
+// CustomerSyncSelector selects the changes made to campaigns and feeds
+// during DateTimeRange, at least one campaign or feed id is required.
+type CustomerSyncSelector struct {
+	XMLName       xml.Name
+	DateTimeRange DateTimeRange `xml:"dateTimeRange"`
+	CampaignIds   []int64       `xml:"campaignIds,omitempty"`
+	FeedIds       []int64       `xml:"feedIds,omitempty"`
+}
+
+// AdGroupChangeData lists the changes of an ad group, ads and criteria
+// are given by id.
+type AdGroupChangeData struct {
+	AdGroupId           int64   `xml:"adGroupId"`
+	AdGroupChangeStatus string  `xml:"adGroupChangeStatus"` // AdGroupChangeStatus: "FIELDS_UNCHANGED", "FIELDS_CHANGED", "NEW"
+	ChangedAds          []int64 `xml:"changedAds"`
+	ChangedCriteria     []int64 `xml:"changedCriteria"`
+	RemovedCriteria     []int64 `xml:"removedCriteria"`
+	ChangedFeeds        []int64 `xml:"changedFeeds"`
+	RemovedFeeds        []int64 `xml:"removedFeeds"`
+}
+
+// CampaignChangeData lists the changes of a campaign and of its ad groups.
+type CampaignChangeData struct {
+	CampaignId              int64               `xml:"campaignId"`
+	CampaignChangeStatus    string              `xml:"campaignChangeStatus"` // CampaignChangeStatus: "FIELDS_UNCHANGED", "FIELDS_CHANGED", "NEW"
+	ChangedAdGroups         []AdGroupChangeData `xml:"changedAdGroups"`
+	AddedCampaignCriteria   []int64             `xml:"addedCampaignCriteria"`
+	RemovedCampaignCriteria []int64             `xml:"removedCampaignCriteria"`
+	ChangedFeeds            []int64             `xml:"changedFeeds"`
+	RemovedFeeds            []int64             `xml:"removedFeeds"`
+}
+
+// FeedChangeData lists the changes of a feed, feed items are given by id.
+type FeedChangeData struct {
+	FeedId           int64   `xml:"feedId"`
+	FeedChangeStatus string  `xml:"feedChangeStatus"` // FeedChangeStatus: "FIELDS_UNCHANGED", "FIELDS_CHANGED", "NEW"
+	ChangedFeedItems []int64 `xml:"changedFeedItems"`
+	RemovedFeedItems []int64 `xml:"removedFeedItems"`
+}
+
+// CustomerChangeData holds the changes of an account, LastChangeTimestamp
+// is the time of the last change, in the time zone of the account.
+type CustomerChangeData struct {
+	ChangedCampaigns    []CampaignChangeData `xml:"changedCampaigns"`
+	ChangedFeeds        []FeedChangeData     `xml:"changedFeeds"`
+	LastChangeTimestamp string               `xml:"lastChangeTimestamp"`
+}
+
+// Empty reports whether no campaign nor feed changed.
+func (d CustomerChangeData) Empty() bool {
+	return len(d.ChangedCampaigns) == 0 && len(d.ChangedFeeds) == 0
+}
+
+// Get returns the changes made to the campaigns and feeds of the selector
+// during its date time range.
+//
+// Example
+//
+//   changes, err := customerSyncService.Get(
+//     CustomerSyncSelector{
+//       DateTimeRange: DateTimeRange{
+//         Min: FormatDateTime(time.Now().Add(-24 * time.Hour)),
+//         Max: FormatDateTime(time.Now()),
+//       },
+//       CampaignIds: []int64{1234, 5678},
+//     },
+//   )
+//   for _, campaign := range changes.ChangedCampaigns {
+//     fmt.Println(campaign.CampaignId, campaign.CampaignChangeStatus)
+//   }
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/CustomerSyncService#get
+//
+func (s *CustomerSyncService) Get(selector CustomerSyncSelector) (changes CustomerChangeData, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *CustomerSyncService) GetContext(ctx context.Context, selector CustomerSyncSelector) (changes CustomerChangeData, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		customerSyncServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     CustomerSyncSelector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return changes, err
+	}
+	getResp := struct {
+		Changes CustomerChangeData `xml:"rval"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return changes, err
+	}
+	return getResp.Changes, err
+}
+
+const defaultCustomerSyncInterval = 5 * time.Minute
+
+// ChangeEvent carries the changes of an account since its previous sync.
+type ChangeEvent struct {
+	CustomerId string
+	Changes    CustomerChangeData
+}
+
+// CustomerSyncPoller polls the changes of accounts and remembers, per
+// account, the timestamp of the last change synced. A change made at that
+// timestamp may be reported again by the next sync.
+//
+// Example
+//
+//   poller := &gads.CustomerSyncPoller{
+//     Interval: time.Minute,
+//     OnChange: func(ctx context.Context, event gads.ChangeEvent) error {
+//       for _, campaign := range event.Changes.ChangedCampaigns {
+//         cache.InvalidateCampaign(event.CustomerId, campaign.CampaignId)
+//       }
+//       return nil
+//     },
+//   }
+//   poller.SetCheckpoint(auth.CustomerId, storedTimestamp)
+//   err := poller.Run(ctx, &auth)
+//
+// Checkpoint and SetCheckpoint may be called while Run is running.
+type CustomerSyncPoller struct {
+	// Interval is the delay between polls, defaults to 5 minutes.
+	Interval time.Duration
+	// CampaignIds and FeedIds restrict the changes synced, all the
+	// campaigns of an account are synced when both are empty.
+	CampaignIds []int64
+	FeedIds     []int64
+	// OnChange is called with the changes of an account, an error stops
+	// Run. It is not called when nothing changed.
+	OnChange func(ctx context.Context, event ChangeEvent) error
+	// Events receives the changes of the accounts as well, when set.
+	Events chan<- ChangeEvent
+
+	mu          sync.Mutex
+	checkpoints map[string]string
+}
+
+// Checkpoint returns the timestamp the next sync of the account
+// customerId starts at, empty before its first sync.
+func (p *CustomerSyncPoller) Checkpoint(customerId string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.checkpoints[customerId]
+}
+
+// SetCheckpoint sets the timestamp the next sync of the account
+// customerId starts at, for instance to resume from a stored Checkpoint.
+func (p *CustomerSyncPoller) SetCheckpoint(customerId, timestamp string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.checkpoints == nil {
+		p.checkpoints = map[string]string{}
+	}
+	p.checkpoints[customerId] = timestamp
+}
+
+// Sync syncs the account of auth once and emits its changes, if any. The
+// first sync of an account without checkpoint only records the current
+// time. The checkpoint moves to the last change synced, or to the end of
+// the range queried when nothing changed.
+func (p *CustomerSyncPoller) Sync(ctx context.Context, auth *Auth) (changes CustomerChangeData, err error) {
+	now := FormatDateTime(time.Now())
+	checkpoint := p.Checkpoint(auth.CustomerId)
+	if checkpoint == "" {
+		p.SetCheckpoint(auth.CustomerId, now)
+		return changes, nil
+	}
+
+	selector := CustomerSyncSelector{
+		DateTimeRange: DateTimeRange{Min: checkpoint, Max: now},
+		CampaignIds:   p.CampaignIds,
+		FeedIds:       p.FeedIds,
+	}
+	if len(selector.CampaignIds) == 0 && len(selector.FeedIds) == 0 {
+		if selector.CampaignIds, err = customerSyncCampaignIds(ctx, auth); err != nil {
+			return changes, err
+		}
+		if len(selector.CampaignIds) == 0 {
+			p.SetCheckpoint(auth.CustomerId, now)
+			return changes, nil
+		}
+	}
+	changes, err = NewCustomerSyncService(auth).GetContext(ctx, selector)
+	if err != nil {
+		return changes, err
+	}
+
+	if changes.Empty() {
+		p.SetCheckpoint(auth.CustomerId, now)
+		return changes, nil
+	}
+
+	event := ChangeEvent{CustomerId: auth.CustomerId, Changes: changes}
+	if p.OnChange != nil {
+		if err := p.OnChange(ctx, event); err != nil {
+			return changes, err
+		}
+	}
+	if p.Events != nil {
+		select {
+		case p.Events <- event:
+		case <-ctx.Done():
+			return changes, ctx.Err()
+		}
+	}
+	// the checkpoint only moves once the changes are handled
+	checkpoint = changes.LastChangeTimestamp
+	if checkpoint == "" {
+		checkpoint = now
+	}
+	p.SetCheckpoint(auth.CustomerId, checkpoint)
+	return changes, nil
+}
+
+// customerSyncCampaignIds returns the ids of all the campaigns of the
+// account of auth.
+func customerSyncCampaignIds(ctx context.Context, auth *Auth) (ids []int64, err error) {
+	pager := NewPager(ctx, NewCampaignService(auth).GetContext, Selector{Fields: []string{"Id"}})
+	for pager.Next() {
+		ids = append(ids, pager.Entry().Id)
+	}
+	return ids, pager.Err()
+}
+
+// Run syncs the accounts every Interval until ctx is done or a sync fails,
+// the accounts are synced one after the other. Run can be called again
+// after a failure, the checkpoints are kept.
+func (p *CustomerSyncPoller) Run(ctx context.Context, auths ...*Auth) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = defaultCustomerSyncInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, auth := range auths {
+			if _, err := p.Sync(ctx, auth); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
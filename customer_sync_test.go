@@ -0,0 +1,111 @@
+package gads
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testCustomerSyncResponse = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>
+<getResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809"><rval>
+  <changedCampaigns>
+    <campaignId>11</campaignId><campaignChangeStatus>FIELDS_UNCHANGED</campaignChangeStatus>
+    <changedAdGroups>
+      <adGroupId>21</adGroupId><adGroupChangeStatus>FIELDS_CHANGED</adGroupChangeStatus>
+      <changedAds>31</changedAds><changedAds>32</changedAds><removedCriteria>41</removedCriteria>
+    </changedAdGroups>
+    <addedCampaignCriteria>51</addedCampaignCriteria>
+  </changedCampaigns>
+  <lastChangeTimestamp>20181018 101502.123456 Europe/Paris</lastChangeTimestamp>
+</rval></getResponse>
+</soap:Body></soap:Envelope>`
+
+func TestCustomerSyncPoller(t *testing.T) {
+	var syncs []string
+	unchanged := false
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), "dateTimeRange") {
+			fmt.Fprint(w, strings.Replace(testEmptyGetResponse, "<totalNumEntries>0</totalNumEntries>",
+				"<totalNumEntries>2</totalNumEntries><entries><id>11</id></entries><entries><id>12</id></entries>", 1))
+			return
+		}
+		syncs = append(syncs, string(body))
+		if unchanged {
+			fmt.Fprint(w, strings.Replace(testEmptyGetResponse, "<totalNumEntries>0</totalNumEntries>", "", 1))
+			return
+		}
+		fmt.Fprint(w, testCustomerSyncResponse)
+	})
+
+	events := make(chan ChangeEvent, 1)
+	var changed []int64
+	poller := &CustomerSyncPoller{
+		OnChange: func(ctx context.Context, event ChangeEvent) error {
+			for _, adGroup := range event.Changes.ChangedCampaigns[0].ChangedAdGroups {
+				changed = append(changed, adGroup.ChangedAds...)
+			}
+			return nil
+		},
+		Events: events,
+	}
+
+	ctx := context.Background()
+	if _, err := poller.Sync(ctx, &auth); err != nil {
+		t.Fatal(err)
+	}
+	if len(syncs) != 0 || poller.Checkpoint(auth.CustomerId) == "" {
+		t.Fatalf("the first sync should only record a checkpoint, got %q", poller.Checkpoint(auth.CustomerId))
+	}
+
+	poller.SetCheckpoint(auth.CustomerId, "20181018 000000 UTC")
+	changes, err := poller.Sync(ctx, &auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"<min>20181018 000000 UTC</min>", "<campaignIds>11</campaignIds>", "<campaignIds>12</campaignIds>"} {
+		if !strings.Contains(syncs[0], want) {
+			t.Errorf("%s not sent in %s", want, syncs[0])
+		}
+	}
+	adGroup := changes.ChangedCampaigns[0].ChangedAdGroups[0]
+	if adGroup.AdGroupChangeStatus != ChangeStatusFieldsChanged || fmt.Sprint(adGroup.RemovedCriteria) != "[41]" || fmt.Sprint(changes.ChangedCampaigns[0].AddedCampaignCriteria) != "[51]" {
+		t.Errorf("unexpected changes %+v", changes)
+	}
+	if fmt.Sprint(changed) != "[31 32]" {
+		t.Errorf("unexpected changed ads %v", changed)
+	}
+	if event := <-events; event.CustomerId != auth.CustomerId || event.Changes.Empty() {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if got := poller.Checkpoint(auth.CustomerId); got != "20181018 101502.123456 Europe/Paris" {
+		t.Errorf("unexpected checkpoint %q", got)
+	}
+
+	poller.CampaignIds = []int64{11}
+	poller.OnChange = func(ctx context.Context, event ChangeEvent) error {
+		return fmt.Errorf("cache unavailable")
+	}
+	poller.SetCheckpoint(auth.CustomerId, "20181018 000000 UTC")
+	if _, err := poller.Sync(ctx, &auth); err == nil || poller.Checkpoint(auth.CustomerId) != "20181018 000000 UTC" {
+		t.Errorf("a failed handler must not move the checkpoint: %v", err)
+	}
+	if strings.Contains(syncs[1], "<campaignIds>12</campaignIds>") {
+		t.Errorf("campaign ids not restricted in %s", syncs[1])
+	}
+
+	// nothing changed, the checkpoint moves to the end of the range queried
+	unchanged = true
+	poller.SetCheckpoint(auth.CustomerId, "20181018 000000 UTC")
+	changes, err = poller.Sync(ctx, &auth)
+	if err != nil || !changes.Empty() {
+		t.Fatalf("unexpected changes %+v: %v", changes, err)
+	}
+	max := syncs[2][strings.Index(syncs[2], "<max>")+len("<max>") : strings.Index(syncs[2], "</max>")]
+	if got := poller.Checkpoint(auth.CustomerId); got != max || got == "20181018 000000 UTC" {
+		t.Errorf("expected the checkpoint to move to %q, got %q", max, got)
+	}
+}
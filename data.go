@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -41,8 +42,14 @@ func NewDataService(auth *Auth) *DataService {
 
 // GetCriterionBidLandscape returns CriterionBidLandscape
 func (s *DataService) GetCriterionBidLandscape(selector Selector) ([]CriterionBidLandscape, int64, error) {
+	return s.GetCriterionBidLandscapeContext(context.Background(), selector)
+}
+
+// GetCriterionBidLandscapeContext is like GetCriterionBidLandscape but binds the request to ctx.
+func (s *DataService) GetCriterionBidLandscapeContext(ctx context.Context, selector Selector) ([]CriterionBidLandscape, int64, error) {
 	selector.XMLName = xml.Name{Space: "", Local: "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		dataServiceUrl,
 		"getCriterionBidLandscape",
 		struct {
@@ -69,8 +76,14 @@ func (s *DataService) GetCriterionBidLandscape(selector Selector) ([]CriterionBi
 
 // GetCampaignCriterionBidLandscape returns CriterionBidLandscape
 func (s *DataService) GetCampaignCriterionBidLandscape(selector Selector) ([]CriterionBidLandscape, int64, error) {
+	return s.GetCampaignCriterionBidLandscapeContext(context.Background(), selector)
+}
+
+// GetCampaignCriterionBidLandscapeContext is like GetCampaignCriterionBidLandscape but binds the request to ctx.
+func (s *DataService) GetCampaignCriterionBidLandscapeContext(ctx context.Context, selector Selector) ([]CriterionBidLandscape, int64, error) {
 	selector.XMLName = xml.Name{Space: "", Local: "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		dataServiceUrl,
 		"getCampaignCriterionBidLandscape",
 		struct {
@@ -22,6 +22,23 @@
 //       },
 //     )
 //
+// Every service method has a Context variant (GetContext, MutateContext,
+// ...) that binds the underlying http request to a context so calls can
+// be cancelled or given a deadline.
+//
+//     ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//     defer cancel()
+//     campaigns, totalCount, err := cs.GetContext(ctx, selector)
+//
+// The services returning large pages (AdGroupCriterionService,
+// AdGroupAdService, ...) also have a ForEach method decoding the entries
+// one at a time from the http body and handing them to a callback.
+//
+//     totalCount, err := cs.ForEach(ctx, selector, func(c gads.Campaign) error {
+//       fmt.Println(c.Id, c.Name)
+//       return nil
+//     })
+//
 // 1. http://www.google.com/adwords/myclientcenter/
 //
 // 2. https://developers.google.com/adwords/api/docs/signingup
@@ -0,0 +1,308 @@
+package gads
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+type DraftService struct {
+	Auth
+}
+
+func NewDraftService(auth *Auth) *DraftService {
+	return &DraftService{Auth: *auth}
+}
+
+// Draft statuses
+const (
+	DraftStatusProposed      = "PROPOSED"
+	DraftStatusArchived      = "ARCHIVED"
+	DraftStatusPromoting     = "PROMOTING"
+	DraftStatusPromoted      = "PROMOTED"
+	DraftStatusPromoteFailed = "PROMOTE_FAILED"
+)
+
+// Draft is a copy of a base campaign whose changes are made to its draft
+// campaign, a campaign whose CampaignTrialType is DRAFT. The changes are
+// applied to the base campaign by promoting the draft, or tested on a
+// share of its traffic by a Trial.
+type Draft struct {
+	DraftId         int64  `xml:"draftId,omitempty"`
+	BaseCampaignId  int64  `xml:"baseCampaignId,omitempty"`
+	DraftName       string `xml:"draftName,omitempty"`
+	DraftStatus     string `xml:"draftStatus,omitempty"` // DraftStatus: "PROPOSED", "ARCHIVED", "PROMOTING", "PROMOTED", "PROMOTE_FAILED"
+	DraftCampaignId int64  `xml:"draftCampaignId,omitempty"`
+	HasRunningTrial bool   `xml:"hasRunningTrial,omitempty"`
+}
+
+// DraftOperations is a map of operations to perform on Draft's
+type DraftOperations map[string][]Draft
+
+// DraftFields is the catalog of the fields accepted by the selectors of
+// DraftService.Get, see NewSelector.
+var DraftFields = FieldCatalog{
+	Service: "DraftService",
+	Selectable: []string{
+		"BaseCampaignId", "DraftCampaignId", "DraftId", "DraftName", "DraftStatus",
+		"HasRunningTrial",
+	},
+	Filterable: []string{
+		"BaseCampaignId", "DraftCampaignId", "DraftId", "DraftName", "DraftStatus",
+		"HasRunningTrial",
+	},
+	Complete: true,
+}
+
+// Get returns an array of Draft's and the total number of Draft's
+// matching the selector.
+//
+// Example
+//
+//   drafts, totalCount, err := draftService.Get(
+//     Selector{
+//       Fields: []string{"DraftId","DraftName","DraftStatus","DraftCampaignId"},
+//       Predicates: []Predicate{
+//         {"BaseCampaignId", "EQUALS", []string{"1234"}},
+//       },
+//     },
+//   )
+//
+// Selectable fields are
+//   "BaseCampaignId", "DraftCampaignId", "DraftId", "DraftName", "DraftStatus",
+//   "HasRunningTrial"
+//
+// filterable fields are
+//   "BaseCampaignId", "DraftCampaignId", "DraftId", "DraftName", "DraftStatus",
+//   "HasRunningTrial"
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/DraftService#get
+//
+func (s *DraftService) Get(selector Selector) (drafts []Draft, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *DraftService) GetContext(ctx context.Context, selector Selector) (drafts []Draft, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		draftServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return drafts, totalCount, err
+	}
+	getResp := struct {
+		Size   int64   `xml:"rval>totalNumEntries"`
+		Drafts []Draft `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return drafts, totalCount, err
+	}
+	return getResp.Drafts, getResp.Size, err
+}
+
+// Mutate allows you to add and modify drafts, returning the modified
+// drafts. Drafts are identified by BaseCampaignId and DraftId.
+//
+// Example
+//
+//  drafts, err := draftService.Mutate(
+//    DraftOperations{
+//      "ADD": {
+//        Draft{BaseCampaignId: 1234, DraftName: "bid strategy test"},
+//      },
+//      "SET": {
+//        Draft{BaseCampaignId: 1234, DraftId: 10, DraftStatus: DraftStatusArchived},
+//      },
+//    },
+//  )
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/DraftService#mutate
+//
+func (s *DraftService) Mutate(draftOperations DraftOperations) (drafts []Draft, err error) {
+	return s.MutateContext(context.Background(), draftOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *DraftService) MutateContext(ctx context.Context, draftOperations DraftOperations) (drafts []Draft, err error) {
+	type draftOperation struct {
+		Action string `xml:"operator"`
+		Draft  Draft  `xml:"operand"`
+	}
+	operations := []draftOperation{}
+	for action, drafts := range draftOperations {
+		for _, draft := range drafts {
+			operations = append(operations,
+				draftOperation{
+					Action: action,
+					Draft:  draft,
+				},
+			)
+		}
+	}
+	mutation := struct {
+		XMLName xml.Name
+		Ops     []draftOperation `xml:"operations"`
+	}{
+		XMLName: xml.Name{
+			Space: baseUrl,
+			Local: "mutate",
+		},
+		Ops: operations,
+	}
+	respBody, err := s.Auth.request(ctx, draftServiceUrl, "mutate", mutation)
+	if err != nil {
+		return drafts, err
+	}
+	mutateResp := struct {
+		BaseResponse
+		Drafts []Draft `xml:"rval>value"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &mutateResp)
+	if err != nil {
+		return drafts, err
+	}
+
+	if len(mutateResp.PartialFailureErrors) > 0 {
+		err = mutateResp.PartialFailureErrors
+	}
+
+	return mutateResp.Drafts, err
+}
+
+// Query returns the drafts matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   drafts, totalCount, err := draftService.Query("SELECT DraftId, DraftStatus WHERE BaseCampaignId = 1234")
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/DraftService#query
+//
+func (s *DraftService) Query(query string) (drafts []Draft, totalCount int64, err error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *DraftService) QueryContext(ctx context.Context, query string) (drafts []Draft, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		draftServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return drafts, totalCount, err
+	}
+	queryResp := struct {
+		Size   int64   `xml:"rval>totalNumEntries"`
+		Drafts []Draft `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return drafts, totalCount, err
+	}
+	return queryResp.Drafts, queryResp.Size, err
+}
+
+// mutateDraft performs a single operation and returns its draft.
+func (s *DraftService) mutateDraft(ctx context.Context, action string, draft Draft) (Draft, error) {
+	drafts, err := s.MutateContext(ctx, DraftOperations{action: {draft}})
+	if err != nil {
+		return draft, err
+	}
+	if len(drafts) == 0 {
+		return draft, errors.New("gads: no draft returned")
+	}
+	return drafts[0], nil
+}
+
+// Create creates a draft of the base campaign, the changes are then made
+// to the campaign DraftCampaignId of the returned draft.
+//
+// Example
+//
+//   draft, err := draftService.Create(ctx, 1234, "bid strategy test")
+//   if err != nil {
+//     log.Fatal(err)
+//   }
+//   campaigns, err := campaignService.MutateContext(ctx, CampaignOperations{
+//     "SET": {
+//       Campaign{Id: draft.DraftCampaignId, BiddingStrategyConfiguration: ...},
+//     },
+//   })
+//
+func (s *DraftService) Create(ctx context.Context, baseCampaignId int64, name string) (draft Draft, err error) {
+	return s.mutateDraft(ctx, "ADD", Draft{BaseCampaignId: baseCampaignId, DraftName: name})
+}
+
+// Promote starts applying the changes of draft to its base campaign, the
+// promotion is asynchronous, see Wait.
+func (s *DraftService) Promote(ctx context.Context, draft Draft) (Draft, error) {
+	return s.mutateDraft(ctx, "SET", Draft{
+		BaseCampaignId: draft.BaseCampaignId,
+		DraftId:        draft.DraftId,
+		DraftStatus:    DraftStatusPromoting,
+	})
+}
+
+// Wait polls draft until it is no longer being promoted, and returns it.
+// ErrDraftPromotionFailed is returned along with drafts failing to
+// promote, DraftAsyncErrorService tells why.
+func (s *DraftService) Wait(ctx context.Context, draft Draft, polling *Polling) (Draft, error) {
+	selector := Selector{
+		Fields: DraftFields.Selectable,
+		Predicates: []Predicate{
+			{"BaseCampaignId", "EQUALS", []string{strconv.FormatInt(draft.BaseCampaignId, 10)}},
+			{"DraftId", "EQUALS", []string{strconv.FormatInt(draft.DraftId, 10)}},
+		},
+	}
+	for attempt := 1; ; attempt++ {
+		if err := polling.wait(ctx, attempt); err != nil {
+			return draft, err
+		}
+
+		drafts, _, err := s.GetContext(ctx, selector)
+		if err != nil {
+			return draft, err
+		}
+		if len(drafts) == 0 {
+			return draft, fmt.Errorf("gads: draft %d of campaign %d not found", draft.DraftId, draft.BaseCampaignId)
+		}
+		draft = drafts[0]
+		switch draft.DraftStatus {
+		case DraftStatusPromoting:
+		case DraftStatusPromoteFailed:
+			return draft, ErrDraftPromotionFailed
+		default:
+			return draft, nil
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package gads
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+type DraftAsyncErrorService struct {
+	Auth
+}
+
+func NewDraftAsyncErrorService(auth *Auth) *DraftAsyncErrorService {
+	return &DraftAsyncErrorService{Auth: *auth}
+}
+
+// DraftAsyncError is an error of the asynchronous promotion of a draft.
+type DraftAsyncError struct {
+	BaseCampaignId  int64
+	DraftId         int64
+	DraftCampaignId int64
+	AsyncError      ApiError
+}
+
+func (e *DraftAsyncError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	for token, err := dec.Token(); err == nil; token, err = dec.Token() {
+		if err != nil {
+			return err
+		}
+		switch start := token.(type) {
+		case xml.StartElement:
+			tag := start.Name.Local
+			switch tag {
+			case "baseCampaignId":
+				if err := dec.DecodeElement(&e.BaseCampaignId, &start); err != nil {
+					return err
+				}
+			case "draftId":
+				if err := dec.DecodeElement(&e.DraftId, &start); err != nil {
+					return err
+				}
+			case "draftCampaignId":
+				if err := dec.DecodeElement(&e.DraftCampaignId, &start); err != nil {
+					return err
+				}
+			case "asyncError":
+				asyncError, err := decodeApiError(dec, start)
+				if err != nil {
+					return err
+				}
+				e.AsyncError = asyncError
+			default:
+				if StrictMode {
+					return fmt.Errorf("unknown DraftAsyncError field %s", tag)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// DraftAsyncErrorFields is the catalog of the fields accepted by the selectors of
+// DraftAsyncErrorService.Get, see NewSelector.
+var DraftAsyncErrorFields = FieldCatalog{
+	Service: "DraftAsyncErrorService",
+	Selectable: []string{
+		"AsyncError", "BaseCampaignId", "DraftCampaignId", "DraftId",
+	},
+	Filterable: []string{
+		"BaseCampaignId", "DraftCampaignId", "DraftId",
+	},
+	Complete: true,
+}
+
+// Get returns an array of DraftAsyncError's and the total number of DraftAsyncError's
+// matching the selector.
+//
+// Example
+//
+//   draftAsyncErrors, totalCount, err := draftAsyncErrorService.Get(
+//     Selector{
+//       Fields: []string{"DraftId","AsyncError"},
+//       Predicates: []Predicate{
+//         {"BaseCampaignId", "EQUALS", []string{"1234"}},
+//         {"DraftId", "EQUALS", []string{"10"}},
+//       },
+//     },
+//   )
+//
+// Selectable fields are
+//   "AsyncError", "BaseCampaignId", "DraftCampaignId", "DraftId"
+//
+// filterable fields are
+//   "BaseCampaignId", "DraftCampaignId", "DraftId"
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/DraftAsyncErrorService#get
+//
+func (s *DraftAsyncErrorService) Get(selector Selector) (draftAsyncErrors []DraftAsyncError, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *DraftAsyncErrorService) GetContext(ctx context.Context, selector Selector) (draftAsyncErrors []DraftAsyncError, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		draftAsyncErrorServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return draftAsyncErrors, totalCount, err
+	}
+	getResp := struct {
+		Size             int64             `xml:"rval>totalNumEntries"`
+		DraftAsyncErrors []DraftAsyncError `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return draftAsyncErrors, totalCount, err
+	}
+	return getResp.DraftAsyncErrors, getResp.Size, err
+}
+
+// Query returns the draft async errors matching an AWQL query, see Select
+// and AWQLStatement to build it.
+//
+// Example
+//
+//   draftAsyncErrors, totalCount, err := draftAsyncErrorService.Query("SELECT DraftId, AsyncError WHERE BaseCampaignId = 1234")
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/DraftAsyncErrorService#query
+//
+func (s *DraftAsyncErrorService) Query(query string) (draftAsyncErrors []DraftAsyncError, totalCount int64, err error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *DraftAsyncErrorService) QueryContext(ctx context.Context, query string) (draftAsyncErrors []DraftAsyncError, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		draftAsyncErrorServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return draftAsyncErrors, totalCount, err
+	}
+	queryResp := struct {
+		Size             int64             `xml:"rval>totalNumEntries"`
+		DraftAsyncErrors []DraftAsyncError `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return draftAsyncErrors, totalCount, err
+	}
+	return queryResp.DraftAsyncErrors, queryResp.Size, err
+}
+
+// ForDraft returns the errors of the promotion of draft, once Wait
+// returned ErrDraftPromotionFailed.
+func (s *DraftAsyncErrorService) ForDraft(ctx context.Context, draft Draft) (errs []ApiError, err error) {
+	pager := NewPager(ctx, s.GetContext, Selector{
+		Fields: DraftAsyncErrorFields.Selectable,
+		Predicates: []Predicate{
+			{"BaseCampaignId", "EQUALS", []string{strconv.FormatInt(draft.BaseCampaignId, 10)}},
+			{"DraftId", "EQUALS", []string{strconv.FormatInt(draft.DraftId, 10)}},
+		},
+	})
+	for pager.Next() {
+		if e := pager.Entry().AsyncError; e != nil {
+			errs = append(errs, e)
+		}
+	}
+	return errs, pager.Err()
+}
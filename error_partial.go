@@ -48,7 +48,13 @@ func (p *PartialFailureError) GetRequestOffset() (int, error) {
 		return *p.Offset, nil
 	}
 
-	a := offsetParse.FindStringSubmatch(p.FieldPath)
+	return parseOperationIndex(p.FieldPath)
+}
+
+// parseOperationIndex extracts the index of the operation from a field
+// path like operations[2].operand.name
+func parseOperationIndex(fieldPath string) (int, error) {
+	a := offsetParse.FindStringSubmatch(fieldPath)
 
 	if len(a) != 2 {
 		return 0, errors.New("unable to find offset")
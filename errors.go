@@ -4,6 +4,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 )
 
@@ -15,8 +16,28 @@ var (
 	ErrMissingReportName         = errors.New("report must have a name")
 	ErrMissingReportType         = errors.New("report must have a type")
 	ErrInvalidReportDownloadType = errors.New("report as an invalid DownloadType")
+	ErrUnknownReportType         = errors.New("unknown report type")
+
+	// Batch jobs
+	ErrBatchJobCanceled   = errors.New("batch job canceled")
+	ErrBatchJobProcessing = errors.New("batch job processing failed")
+
+	// Drafts and trials
+	ErrDraftPromotionFailed = errors.New("draft promotion failed")
+	ErrTrialFailed          = errors.New("trial creation or promotion failed")
 )
 
+// HTTPStatusError is returned when the api answers with an error status
+// code and a body that isn't a soap envelope, typically from a gateway.
+type HTTPStatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return "unexpected http status " + e.Status
+}
+
 type OperationError struct {
 	Code      int64  `xml:"OperationError>Code"`
 	Details   string `xml:"OperationError>Details"`
@@ -24,6 +45,80 @@ type OperationError struct {
 	Message   string `xml:"OperationError>Message"`
 }
 
+// ApiError is implemented by every error returned by the api in an
+// ApiExceptionFault. The concrete types can be extracted with errors.As
+// from the error returned by any service call.
+//
+//   var notFound gads.EntityNotFound
+//   if errors.As(err, &notFound) {
+//     idx, _ := notFound.GetOperationIndex()
+//     ...
+//   }
+type ApiError interface {
+	error
+	GetFieldPath() string
+	GetTrigger() string
+	GetReason() string
+	GetErrorString() string
+	// GetOperationIndex returns the index of the operation of a mutate
+	// the error refers to.
+	GetOperationIndex() (int, error)
+}
+
+// ErrorReason matches, with errors.Is, the ApiError having this reason
+// or error string.
+//
+//   errors.Is(err, gads.ErrorReason("INVALID_ID"))
+//   errors.Is(err, gads.ErrorReason("EntityNotFound.INVALID_ID"))
+type ErrorReason string
+
+func (r ErrorReason) Error() string {
+	return string(r)
+}
+
+// CommonApiError holds the fields shared by every ApiError.
+//
+// see https://developers.google.com/adwords/api/docs/reference/v201809/CampaignService.ApiError
+type CommonApiError struct {
+	FieldPath    string `xml:"fieldPath"`
+	Trigger      string `xml:"trigger"`
+	ErrorString  string `xml:"errorString"`
+	Reason       string `xml:"reason"`
+	ApiErrorType string `xml:"ApiError.Type"`
+}
+
+// Error returns a summary of the error
+func (e CommonApiError) Error() string {
+	m := e.ErrorString
+	if m == "" {
+		m = e.ApiErrorType + "." + e.Reason
+	}
+	if e.FieldPath != "" {
+		m += " @ " + e.FieldPath
+	}
+	if e.Trigger != "" {
+		m += " ; trigger:'" + e.Trigger + "'"
+	}
+	return m
+}
+
+func (e CommonApiError) GetFieldPath() string   { return e.FieldPath }
+func (e CommonApiError) GetTrigger() string     { return e.Trigger }
+func (e CommonApiError) GetReason() string      { return e.Reason }
+func (e CommonApiError) GetErrorString() string { return e.ErrorString }
+
+// GetOperationIndex returns the index of the operation parsed from the
+// field path.
+func (e CommonApiError) GetOperationIndex() (int, error) {
+	return parseOperationIndex(e.FieldPath)
+}
+
+// Is reports whether target is an ErrorReason matching this error.
+func (e CommonApiError) Is(target error) bool {
+	r, ok := target.(ErrorReason)
+	return ok && r != "" && (string(r) == e.Reason || string(r) == e.ErrorString)
+}
+
 type BudgetError struct {
 	Path    string `xml:"fieldPath"`
 	String  string `xml:"errorString"`
@@ -31,61 +126,315 @@ type BudgetError struct {
 	Reason  string `xml:"reason"`
 }
 
+func (e BudgetError) common() CommonApiError {
+	return CommonApiError{
+		FieldPath:    e.Path,
+		Trigger:      e.Trigger,
+		ErrorString:  e.String,
+		Reason:       e.Reason,
+		ApiErrorType: "BudgetError",
+	}
+}
+
+func (e BudgetError) Error() string                   { return e.common().Error() }
+func (e BudgetError) GetFieldPath() string            { return e.Path }
+func (e BudgetError) GetTrigger() string              { return e.Trigger }
+func (e BudgetError) GetReason() string               { return e.Reason }
+func (e BudgetError) GetErrorString() string          { return e.String }
+func (e BudgetError) GetOperationIndex() (int, error) { return parseOperationIndex(e.Path) }
+func (e BudgetError) Is(target error) bool            { return e.common().Is(target) }
+
 type CriterionError struct {
-	FieldPath   string `xml:"fieldPath"`
-	Trigger     string `xml:"trigger"`
-	ErrorString string `xml:"errorString"`
-	Reason      string `xml:"reason"`
+	CommonApiError
 }
 
 type TargetError struct {
-	FieldPath   string `xml:"fieldPath"`
-	Trigger     string `xml:"trigger"`
-	ErrorString string `xml:"errorString"`
-	Reason      string `xml:"reason"`
+	CommonApiError
 }
 
 type AdGroupServiceError struct {
-	FieldPath   string `xml:"fieldPath"`
-	Trigger     string `xml:"trigger"`
-	ErrorString string `xml:"errorString"`
-	Reason      string `xml:"reason"`
+	CommonApiError
 }
 
 type NotEmptyError struct {
-	FieldPath   string `xml:"fieldPath"`
-	Trigger     string `xml:"trigger"`
-	ErrorString string `xml:"errorString"`
-	Reason      string `xml:"reason"`
+	CommonApiError
 }
 
 type AdError struct {
-	FieldPath   string `xml:"fieldPath"`
-	Trigger     string `xml:"trigger"`
-	ErrorString string `xml:"errorString"`
-	Reason      string `xml:"reason"`
+	CommonApiError
 }
 
 // if you exceed the quota given by google
 type RateExceededError struct {
-	RateName          string `xml:"rateName"`  // For example OperationsByMinute
-	RateScope         string `xml:"rateScope"` // ACCOUNT or DEVELOPER
-	ErrorString       string `xml:"errorString"`
-	Reason            string `xml:"reason"`
+	CommonApiError
+	RateName          string `xml:"rateName"`          // For example OperationsByMinute
+	RateScope         string `xml:"rateScope"`         // ACCOUNT or DEVELOPER
 	RetryAfterSeconds uint   `xml:"retryAfterSeconds"` // Try again in...
 }
 
+// EntityNotFound is returned when the entity referenced doesn't exist
+type EntityNotFound struct {
+	CommonApiError
+}
+
+// EntityAccessDenied is returned when the entity can't be accessed
+type EntityAccessDenied struct {
+	CommonApiError
+}
+
+// EntityCountLimitExceeded is returned when an operation would create more
+// entities than the account allows
+type EntityCountLimitExceeded struct {
+	CommonApiError
+	EnclosingID      string `xml:"enclosingId"`
+	Limit            int64  `xml:"limit"`
+	AccountLimitType string `xml:"accountLimitType"`
+	ExistingCount    int64  `xml:"existingCount"`
+}
+
+// AuthenticationError is returned when the oauth2 credentials are rejected
+type AuthenticationError struct {
+	CommonApiError
+}
+
+// AuthorizationError is returned when the credentials can't access the
+// customer
+type AuthorizationError struct {
+	CommonApiError
+}
+
+// QuotaCheckError is returned when the developer token is not allowed to
+// perform more calls
+type QuotaCheckError struct {
+	CommonApiError
+}
+
+// InternalApiError is an unexpected error of the api, it is usually
+// transient
+type InternalApiError struct {
+	CommonApiError
+}
+
+// DatabaseError is returned on concurrent modifications of an entity
+type DatabaseError struct {
+	CommonApiError
+}
+
+// OperationAccessDenied is returned when the operator is not allowed on
+// the entity
+type OperationAccessDenied struct {
+	CommonApiError
+}
+
+// OperatorError is returned when the operator is not supported
+type OperatorError struct {
+	CommonApiError
+}
+
+// RangeError is returned when a value is out of its allowed range
+type RangeError struct {
+	CommonApiError
+}
+
+// StringLengthError is returned when a string is too long or too short
+type StringLengthError struct {
+	CommonApiError
+}
+
+// StringFormatError is returned when a string contains illegal characters
+type StringFormatError struct {
+	CommonApiError
+}
+
+// RequiredError is returned when a required field is missing
+type RequiredError struct {
+	CommonApiError
+}
+
+// ReadOnlyError is returned when a read only field is set
+type ReadOnlyError struct {
+	CommonApiError
+}
+
+// DistinctError is returned when a list contains duplicates
+type DistinctError struct {
+	CommonApiError
+}
+
+// IdError is returned when an id is invalid
+type IdError struct {
+	CommonApiError
+}
+
+// RequestError is returned when the request itself is invalid
+type RequestError struct {
+	CommonApiError
+}
+
+// SelectorError is returned when a selector is invalid
+type SelectorError struct {
+	CommonApiError
+}
+
+// SizeLimitError is returned when a request or response is too large
+type SizeLimitError struct {
+	CommonApiError
+}
+
+// RejectedError is returned when a value is rejected
+type RejectedError struct {
+	CommonApiError
+}
+
+// CampaignError is returned by CampaignService operations
+type CampaignError struct {
+	CommonApiError
+}
+
+// AdGroupAdError is returned by AdGroupAdService operations
+type AdGroupAdError struct {
+	CommonApiError
+}
+
+// AdGroupCriterionError is returned by AdGroupCriterionService operations
+type AdGroupCriterionError struct {
+	CommonApiError
+}
+
+// BiddingErrors is returned when the bids are invalid
+type BiddingErrors struct {
+	CommonApiError
+}
+
+// PolicyViolationKey identifies a policy violation
+type PolicyViolationKey struct {
+	PolicyName    string `xml:"policyName"`
+	ViolatingText string `xml:"violatingText"`
+}
+
+// PolicyViolationPart is the part of the text violating the policy
+type PolicyViolationPart struct {
+	Index  int64 `xml:"index"`
+	Length int64 `xml:"length"`
+}
+
+// PolicyViolationError is returned when a text doesn't comply with the
+// advertising policies. Exemptable violations can be submitted again with
+// an exemption request.
+type PolicyViolationError struct {
+	CommonApiError
+	Key                       PolicyViolationKey    `xml:"key"`
+	ExternalPolicyName        string                `xml:"externalPolicyName"`
+	ExternalPolicyUrl         string                `xml:"externalPolicyUrl"`
+	ExternalPolicyDescription string                `xml:"externalPolicyDescription"`
+	IsExemptable              bool                  `xml:"isExemptable"`
+	ViolatingParts            []PolicyViolationPart `xml:"violatingParts"`
+}
+
+// PolicyFindingError is returned when an entity has a policy finding
+type PolicyFindingError struct {
+	CommonApiError
+	PolicyName        string `xml:"policyName"`
+	PolicyDescription string `xml:"policyDescription"`
+}
+
+// UnknownError holds every error type not yet mapped to a struct,
+// ApiErrorType tells its actual type.
 type UnknownError struct {
-	FieldPath   string `xml:"fieldPath"`
-	Trigger     string `xml:"trigger"`
-	ErrorString string `xml:"errorString"`
-	Reason      string `xml:"reason"`
+	CommonApiError
 }
 
 type ApiExceptionFault struct {
-	Message string        `xml:"message"`
-	Type    string        `xml:"ApplicationException.Type"`
-	Errors  []interface{} `xml:"errors"`
+	Message string     `xml:"message"`
+	Type    string     `xml:"ApplicationException.Type"`
+	Errors  []ApiError `xml:"errors"`
+}
+
+// decodeApiError decodes an ApiError according to its xsi:type
+func decodeApiError(dec *xml.Decoder, start xml.StartElement) (ApiError, error) {
+	errorType, _ := findAttr(start.Attr, xml.Name{Space: "http://www.w3.org/2001/XMLSchema-instance", Local: "type"})
+	// batch job results qualify the type, like ns2:EntityNotFound
+	if i := strings.IndexByte(errorType, ':'); i >= 0 {
+		errorType = errorType[i+1:]
+	}
+	var e ApiError
+	switch errorType {
+	case "AdError":
+		e = &AdError{}
+	case "AdGroupAdError":
+		e = &AdGroupAdError{}
+	case "AdGroupCriterionError":
+		e = &AdGroupCriterionError{}
+	case "AdGroupServiceError":
+		e = &AdGroupServiceError{}
+	case "AuthenticationError":
+		e = &AuthenticationError{}
+	case "AuthorizationError":
+		e = &AuthorizationError{}
+	case "BiddingErrors":
+		e = &BiddingErrors{}
+	case "BudgetError":
+		e = &BudgetError{}
+	case "CampaignError":
+		e = &CampaignError{}
+	case "CriterionError":
+		e = &CriterionError{}
+	case "DatabaseError":
+		e = &DatabaseError{}
+	case "DistinctError":
+		e = &DistinctError{}
+	case "EntityAccessDenied":
+		e = &EntityAccessDenied{}
+	case "EntityCountLimitExceeded":
+		e = &EntityCountLimitExceeded{}
+	case "EntityNotFound":
+		e = &EntityNotFound{}
+	case "IdError":
+		e = &IdError{}
+	case "InternalApiError":
+		e = &InternalApiError{}
+	case "NotEmptyError":
+		e = &NotEmptyError{}
+	case "OperationAccessDenied":
+		e = &OperationAccessDenied{}
+	case "OperatorError":
+		e = &OperatorError{}
+	case "PolicyFindingError":
+		e = &PolicyFindingError{}
+	case "PolicyViolationError":
+		e = &PolicyViolationError{}
+	case "QuotaCheckError":
+		e = &QuotaCheckError{}
+	case "RangeError":
+		e = &RangeError{}
+	case "RateExceededError":
+		e = &RateExceededError{}
+	case "ReadOnlyError":
+		e = &ReadOnlyError{}
+	case "RejectedError":
+		e = &RejectedError{}
+	case "RequestError":
+		e = &RequestError{}
+	case "RequiredError":
+		e = &RequiredError{}
+	case "SelectorError":
+		e = &SelectorError{}
+	case "SizeLimitError":
+		e = &SizeLimitError{}
+	case "StringFormatError":
+		e = &StringFormatError{}
+	case "StringLengthError":
+		e = &StringLengthError{}
+	case "TargetError":
+		e = &TargetError{}
+	default:
+		e = &UnknownError{}
+	}
+	if err := dec.DecodeElement(e, &start); err != nil {
+		return nil, err
+	}
+	// errors are kept by value
+	return reflect.ValueOf(e).Elem().Interface().(ApiError), nil
 }
 
 func (aes *ApiExceptionFault) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) (err error) {
@@ -102,41 +451,11 @@ func (aes *ApiExceptionFault) UnmarshalXML(dec *xml.Decoder, start xml.StartElem
 					return err
 				}
 			case "errors":
-				errorType, _ := findAttr(start.Attr, xml.Name{Space: "http://www.w3.org/2001/XMLSchema-instance", Local: "type"})
-				switch errorType {
-				case "CriterionError":
-					e := CriterionError{}
-					dec.DecodeElement(&e, &start)
-					aes.Errors = append(aes.Errors, e)
-				case "TargetError":
-					e := TargetError{}
-					dec.DecodeElement(&e, &start)
-					aes.Errors = append(aes.Errors, e)
-				case "BudgetError":
-					e := BudgetError{}
-					dec.DecodeElement(&e, &start)
-					aes.Errors = append(aes.Errors, e)
-				case "AdGroupServiceError":
-					e := AdGroupServiceError{}
-					dec.DecodeElement(&e, &start)
-					aes.Errors = append(aes.Errors, e)
-				case "NotEmptyError":
-					e := NotEmptyError{}
-					dec.DecodeElement(&e, &start)
-					aes.Errors = append(aes.Errors, e)
-				case "AdError":
-					e := AdError{}
-					dec.DecodeElement(&e, &start)
-					aes.Errors = append(aes.Errors, e)
-				case "RateExceededError":
-					e := RateExceededError{}
-					dec.DecodeElement(&e, &start)
-					aes.Errors = append(aes.Errors, e)
-				default:
-					e := UnknownError{}
-					dec.DecodeElement(&e, &start)
-					aes.Errors = append(aes.Errors, e)
+				e, err := decodeApiError(dec, start)
+				if err != nil {
+					return err
 				}
+				aes.Errors = append(aes.Errors, e)
 			case "reason":
 				break
 			default:
@@ -149,6 +468,9 @@ func (aes *ApiExceptionFault) UnmarshalXML(dec *xml.Decoder, start xml.StartElem
 
 type ErrorsType struct {
 	ApiExceptionFaults []ApiExceptionFault `xml:"ApiExceptionFault"`
+	// Header of the response that carried the faults, its RequestId
+	// identifies the call for Google support.
+	Header ResponseHeader `xml:"-"`
 }
 
 func (f ErrorsType) Error() string {
@@ -159,6 +481,23 @@ func (f ErrorsType) Error() string {
 	return strings.Join(errors, "\n")
 }
 
+// ApiErrors returns the errors of every fault
+func (f ErrorsType) ApiErrors() (apiErrors []ApiError) {
+	for _, aef := range f.ApiExceptionFaults {
+		apiErrors = append(apiErrors, aef.Errors...)
+	}
+	return apiErrors
+}
+
+// Unwrap gives errors.Is and errors.As access to the ApiErrors
+func (f ErrorsType) Unwrap() []error {
+	errs := []error{}
+	for _, e := range f.ApiErrors() {
+		errs = append(errs, e)
+	}
+	return errs
+}
+
 type Fault struct {
 	XMLName     xml.Name   `xml:"Fault"`
 	FaultCode   string     `xml:"faultcode"`
@@ -169,3 +508,8 @@ type Fault struct {
 func (f Fault) Error() string {
 	return f.FaultString + " - " + f.Errors.Error()
 }
+
+// Unwrap gives errors.Is and errors.As access to the ApiErrors
+func (f Fault) Unwrap() []error {
+	return f.Errors.Unwrap()
+}
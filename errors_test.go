@@ -0,0 +1,80 @@
+package gads
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+const testEntityNotFoundFault = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <soap:Fault>
+      <faultcode>soap:Client</faultcode>
+      <faultstring>[EntityNotFound.INVALID_ID @ operations[1].operand.id]</faultstring>
+      <detail>
+        <ApiExceptionFault xmlns="https://adwords.google.com/api/adwords/cm/v201809">
+          <message>[EntityNotFound.INVALID_ID @ operations[1].operand.id]</message>
+          <ApplicationException.Type>ApiException</ApplicationException.Type>
+          <errors xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="EntityNotFound">
+            <fieldPath>operations[1].operand.id</fieldPath>
+            <trigger>4242</trigger>
+            <errorString>EntityNotFound.INVALID_ID</errorString>
+            <ApiError.Type>EntityNotFound</ApiError.Type>
+            <reason>INVALID_ID</reason>
+          </errors>
+          <errors xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="ShiningNewError">
+            <fieldPath>operations[0].operand</fieldPath>
+            <trigger></trigger>
+            <errorString>ShiningNewError.UNKNOWN</errorString>
+            <ApiError.Type>ShiningNewError</ApiError.Type>
+            <reason>UNKNOWN</reason>
+          </errors>
+        </ApiExceptionFault>
+      </detail>
+    </soap:Fault>
+  </soap:Body>
+</soap:Envelope>`
+
+func TestApiErrors(t *testing.T) {
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, testEntityNotFoundFault)
+	})
+
+	_, err := NewCampaignService(&auth).Mutate(CampaignOperations{"SET": {Campaign{Id: 1}, Campaign{Id: 4242}}})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var notFound EntityNotFound
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected an EntityNotFound in %#v", err)
+	}
+	if notFound.Reason != "INVALID_ID" || notFound.Trigger != "4242" {
+		t.Errorf("unexpected error content %#v", notFound)
+	}
+	if idx, err := notFound.GetOperationIndex(); err != nil || idx != 1 {
+		t.Errorf("expected operation index 1, got %d %v", idx, err)
+	}
+	if notFound.Error() != "EntityNotFound.INVALID_ID @ operations[1].operand.id ; trigger:'4242'" {
+		t.Errorf("unexpected message %q", notFound.Error())
+	}
+
+	if !errors.Is(err, ErrorReason("INVALID_ID")) || !errors.Is(err, ErrorReason("EntityNotFound.INVALID_ID")) {
+		t.Error("errors.Is should match the reason and the error string")
+	}
+	if errors.Is(err, ErrorReason("RATE_EXCEEDED")) {
+		t.Error("errors.Is should not match another reason")
+	}
+
+	var unknown UnknownError
+	if !errors.As(err, &unknown) || unknown.ApiErrorType != "ShiningNewError" {
+		t.Errorf("expected an UnknownError with its type, got %#v", unknown)
+	}
+
+	var apiErr ApiError
+	if !errors.As(Fault{Errors: *err.(*ErrorsType)}, &apiErr) {
+		t.Error("expected Fault to unwrap to its ApiErrors")
+	}
+}
@@ -1,9 +1,13 @@
 package gads
 
+// ExperimentService was sunset by the api.
+//
+// Deprecated: use DraftService and TrialService.
 type ExperimentService struct {
 	Auth
 }
 
+// Deprecated: use NewDraftService and NewTrialService.
 func NewExperimentService(auth *Auth) *ExperimentService {
 	return &ExperimentService{Auth: *auth}
 }
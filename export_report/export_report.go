@@ -0,0 +1,103 @@
+// export_report converts a report to Parquet or JSON lines.
+//
+// The report is either a file downloaded beforehand, or the result of an
+// AWQL query ran with the credentials of -config_json:
+//
+//     export_report -in campaigns.csv.gz -download_format GZIPPED_CSV -format parquet -out campaigns.parquet
+//     export_report -awql "SELECT CampaignId, Date, Impressions FROM CAMPAIGN_PERFORMANCE_REPORT DURING LAST_7_DAYS" -format jsonl
+//
+// Column types are looked up in the report type catalog, reports
+// downloaded without their report header need -report_type.
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/querian/gads"
+	"github.com/querian/gads/reportexport"
+)
+
+var (
+	format         = flag.String("format", "jsonl", "output format, jsonl or parquet")
+	in             = flag.String("in", "-", "downloaded report, - for the standard input")
+	out            = flag.String("out", "-", "output file, - for the standard output")
+	downloadFormat = flag.String("download_format", "CSV", "download format of the report")
+	reportType     = flag.String("report_type", "", "report type of reports downloaded without their report header")
+	awql           = flag.String("awql", "", "AWQL query to download instead of reading -in")
+)
+
+func main() {
+	flag.Parse()
+	ctx := context.Background()
+
+	var report io.Reader
+	switch {
+	case *awql != "":
+		st, err := gads.ParseAWQL(*awql)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *reportType == "" {
+			*reportType = st.From
+		}
+		config, err := gads.NewCredentials(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+		defer cancel()
+		body, err := gads.NewAWQLClient(&config.Auth).DownloadContext(ctx, gads.AWQLRequest{
+			Query:  *awql,
+			Format: gads.DownloadFormat(*downloadFormat),
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer body.Close()
+		report = body
+	case *in == "-":
+		report = os.Stdin
+	default:
+		f, err := os.Open(*in)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		report = f
+	}
+
+	dec, err := gads.NewReportDecoder(report, gads.DownloadFormat(*downloadFormat))
+	if err != nil {
+		log.Fatal(err)
+	}
+	dec.ReportType = *reportType
+
+	w := os.Stdout
+	if *out != "-" {
+		if w, err = os.Create(*out); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var rows int64
+	switch *format {
+	case "jsonl":
+		rows, err = reportexport.WriteJSONL(w, dec)
+	case "parquet":
+		rows, err = reportexport.WriteParquet(w, dec, nil)
+	default:
+		log.Fatalf("unknown format %q", *format)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("%d rows exported", rows)
+}
@@ -1,5 +1,10 @@
 package gads
 
+import (
+	"context"
+	"encoding/xml"
+)
+
 type FeedService struct {
 	Auth
 }
@@ -7,3 +12,288 @@ type FeedService struct {
 func NewFeedService(auth *Auth) *FeedService {
 	return &FeedService{Auth: *auth}
 }
+
+// Feed attribute types
+const (
+	FeedAttributeTypeInt64        = "INT64"
+	FeedAttributeTypeDouble       = "DOUBLE"
+	FeedAttributeTypeString       = "STRING"
+	FeedAttributeTypeDate         = "DATE"
+	FeedAttributeTypeUrl          = "URL"
+	FeedAttributeTypeBoolean      = "BOOLEAN"
+	FeedAttributeTypeDateTime     = "DATE_TIME"
+	FeedAttributeTypePrice        = "PRICE"
+	FeedAttributeTypeInt64List    = "INT64_LIST"
+	FeedAttributeTypeDoubleList   = "DOUBLE_LIST"
+	FeedAttributeTypeStringList   = "STRING_LIST"
+	FeedAttributeTypeDateList     = "DATE_LIST"
+	FeedAttributeTypeUrlList      = "URL_LIST"
+	FeedAttributeTypeBooleanList  = "BOOLEAN_LIST"
+	FeedAttributeTypeDateTimeList = "DATE_TIME_LIST"
+)
+
+// FeedAttribute is a column of a feed.
+type FeedAttribute struct {
+	Id          int64  `xml:"id,omitempty"`
+	Name        string `xml:"name"`
+	Type        string `xml:"type"` // Type: "INT64", "STRING", "URL_LIST", ...
+	IsPartOfKey bool   `xml:"isPartOfKey,omitempty"`
+}
+
+// OAuthInfo holds the credentials the api uses to read a Google My
+// Business account.
+type OAuthInfo struct {
+	HttpMethod              string `xml:"httpMethod"`
+	HttpRequestUrl          string `xml:"httpRequestUrl"`
+	HttpAuthorizationHeader string `xml:"httpAuthorizationHeader"`
+}
+
+// Chain is a retail chain of an affiliate location feed.
+type Chain struct {
+	ChainId int64 `xml:"chainId"`
+}
+
+// SystemFeedGenerationData tells where the items of a system generated
+// feed come from. Type is PlacesLocationFeedData for Google My Business
+// locations or AffiliateLocationFeedData for retail chains, only the
+// fields of that type are used.
+type SystemFeedGenerationData struct {
+	Type string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+
+	// PlacesLocationFeedData
+	OAuthInfo                 *OAuthInfo `xml:"oAuthInfo,omitempty"`
+	EmailAddress              string     `xml:"emailAddress,omitempty"`
+	BusinessAccountIdentifier string     `xml:"businessAccountIdentifier,omitempty"`
+	BusinessNameFilter        string     `xml:"businessNameFilter,omitempty"`
+	CategoryFilters           []string   `xml:"categoryFilters,omitempty"`
+	LabelFilters              []string   `xml:"labelFilters,omitempty"`
+
+	// AffiliateLocationFeedData
+	Chains           []Chain `xml:"chains,omitempty"`
+	RelationshipType string  `xml:"relationshipType,omitempty"` // RelationshipType: "GENERAL_RETAILER"
+}
+
+// Feed represents a feed, the items of a custom feed are managed with
+// FeedItemService and serve once mapped to a placeholder type with
+// FeedMappingService.
+type Feed struct {
+	Id                       int64                     `xml:"id,omitempty"`
+	Name                     string                    `xml:"name,omitempty"`
+	Attributes               []FeedAttribute           `xml:"attributes,omitempty"`
+	Status                   string                    `xml:"status,omitempty"` // Status: "ENABLED", "REMOVED"
+	Origin                   string                    `xml:"origin,omitempty"` // Origin: "USER", "ADWORDS"
+	SystemFeedGenerationData *SystemFeedGenerationData `xml:"systemFeedGenerationData,omitempty"`
+}
+
+// Attribute returns the attribute of the feed named name.
+func (f Feed) Attribute(name string) (FeedAttribute, bool) {
+	for _, a := range f.Attributes {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return FeedAttribute{}, false
+}
+
+// FeedOperations is a map of operations to perform on Feed's
+type FeedOperations map[string][]Feed
+
+// FeedFields is the catalog of the fields accepted by the selectors of
+// FeedService.Get, see NewSelector.
+var FeedFields = FieldCatalog{
+	Service: "FeedService",
+	Selectable: []string{
+		"Attributes", "FeedStatus", "Id", "Name", "Origin",
+		"SystemFeedGenerationData",
+	},
+	Filterable: []string{
+		"FeedStatus", "Id", "Name", "Origin",
+	},
+	Complete: true,
+}
+
+// Get returns an array of Feed's and the total number of Feed's matching
+// the selector.
+//
+// Example
+//
+//   feeds, totalCount, err := feedService.Get(
+//     Selector{
+//       Fields: []string{"Id","Name","Attributes","FeedStatus"},
+//       Predicates: []Predicate{
+//         {"FeedStatus", "EQUALS", []string{"ENABLED"}},
+//         {"Origin", "EQUALS", []string{"USER"}},
+//       },
+//     },
+//   )
+//
+// Selectable fields are
+//   "Attributes", "FeedStatus", "Id", "Name", "Origin",
+//   "SystemFeedGenerationData"
+//
+// filterable fields are
+//   "FeedStatus", "Id", "Name", "Origin"
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/FeedService#get
+//
+func (s *FeedService) Get(selector Selector) (feeds []Feed, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *FeedService) GetContext(ctx context.Context, selector Selector) (feeds []Feed, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		feedServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return feeds, totalCount, err
+	}
+	getResp := struct {
+		Size  int64  `xml:"rval>totalNumEntries"`
+		Feeds []Feed `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return feeds, totalCount, err
+	}
+	return getResp.Feeds, getResp.Size, err
+}
+
+// Mutate allows you to add, modify and remove feeds, returning the
+// modified feeds. The attributes of a feed can be added to but neither
+// modified nor removed.
+//
+// Example
+//
+//  feeds, err := feedService.Mutate(
+//    FeedOperations{
+//      "ADD": {
+//        Feed{
+//          Name: "sitelinks",
+//          Attributes: []FeedAttribute{
+//            {Name: "Link Text", Type: FeedAttributeTypeString},
+//            {Name: "Final URLs", Type: FeedAttributeTypeUrlList},
+//            {Name: "Line 2", Type: FeedAttributeTypeString},
+//            {Name: "Line 3", Type: FeedAttributeTypeString},
+//          },
+//          Origin: "USER",
+//        },
+//      },
+//      "REMOVE": {
+//        Feed{Id: 10},
+//      },
+//    },
+//  )
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/FeedService#mutate
+//
+func (s *FeedService) Mutate(feedOperations FeedOperations) (feeds []Feed, err error) {
+	return s.MutateContext(context.Background(), feedOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *FeedService) MutateContext(ctx context.Context, feedOperations FeedOperations) (feeds []Feed, err error) {
+	type feedOperation struct {
+		Action string `xml:"operator"`
+		Feed   Feed   `xml:"operand"`
+	}
+	operations := []feedOperation{}
+	for action, feeds := range feedOperations {
+		for _, feed := range feeds {
+			operations = append(operations,
+				feedOperation{
+					Action: action,
+					Feed:   feed,
+				},
+			)
+		}
+	}
+	mutation := struct {
+		XMLName xml.Name
+		Ops     []feedOperation `xml:"operations"`
+	}{
+		XMLName: xml.Name{
+			Space: baseUrl,
+			Local: "mutate",
+		},
+		Ops: operations,
+	}
+	respBody, err := s.Auth.request(ctx, feedServiceUrl, "mutate", mutation)
+	if err != nil {
+		return feeds, err
+	}
+	mutateResp := struct {
+		BaseResponse
+		Feeds []Feed `xml:"rval>value"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &mutateResp)
+	if err != nil {
+		return feeds, err
+	}
+
+	if len(mutateResp.PartialFailureErrors) > 0 {
+		err = mutateResp.PartialFailureErrors
+	}
+
+	return mutateResp.Feeds, err
+}
+
+// Query returns the feeds matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   feeds, totalCount, err := feedService.Query("SELECT Id, Name, Attributes WHERE Origin = 'USER'")
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/FeedService#query
+//
+func (s *FeedService) Query(query string) (feeds []Feed, totalCount int64, err error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *FeedService) QueryContext(ctx context.Context, query string) (feeds []Feed, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		feedServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return feeds, totalCount, err
+	}
+	queryResp := struct {
+		Size  int64  `xml:"rval>totalNumEntries"`
+		Feeds []Feed `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return feeds, totalCount, err
+	}
+	return queryResp.Feeds, queryResp.Size, err
+}
@@ -1,6 +1,9 @@
 package gads
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 type FeedItemService struct {
 	Auth
@@ -25,6 +28,14 @@ type FeedItemAttributeValue struct {
 	DoubleValues  *[]float64 `xml:"doubleValues,omitempty"`
 	BooleanValues *[]bool    `xml:"booleanValues,omitempty"`
 	StringValues  *[]string  `xml:"stringValues,omitempty"`
+	// MoneyWithCurrencyValue is the value of PRICE attributes
+	MoneyWithCurrencyValue *MoneyWithCurrency `xml:"moneyWithCurrencyValue,omitempty"`
+}
+
+// MoneyWithCurrency is an amount in micros of a currency like "EUR".
+type MoneyWithCurrency struct {
+	MicroAmount  int64  `xml:"money>microAmount"`
+	CurrencyCode string `xml:"currencyCode"`
 }
 
 type FeedItemOperations map[string][]FeedItem
@@ -62,8 +73,14 @@ func NewFeedItemService(auth *Auth) *FeedItemService {
 }
 
 func (s *FeedItemService) Get(selector Selector) (feedItems []FeedItem, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *FeedItemService) GetContext(ctx context.Context, selector Selector) (feedItems []FeedItem, totalCount int64, err error) {
 	selector.XMLName = xml.Name{"", "selector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		feedItemServiceUrl,
 		"get",
 		struct {
@@ -91,7 +108,32 @@ func (s *FeedItemService) Get(selector Selector) (feedItems []FeedItem, totalCou
 	return getResp.FeedItems, getResp.Size, err
 }
 
+// ForEach streams the feed items matching the selector to fn, decoding them
+// one at a time from the response instead of holding the whole page in
+// memory. Returning ErrStopStream from fn stops the stream without error,
+// any other error is returned by ForEach.
+//
+// Example
+//
+//   totalCount, err := feedItemService.ForEach(ctx, selector, func(feedItem FeedItem) error {
+//     fmt.Println(feedItem.FeedItemID)
+//     return nil
+//   })
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/FeedItemService#get
+//
+func (s *FeedItemService) ForEach(ctx context.Context, selector Selector, fn func(FeedItem) error) (totalCount int64, err error) {
+	return streamGet[[]FeedItem](ctx, &s.Auth, feedItemServiceUrl, "get", getSelector(feedItemServiceUrl, selector), fn)
+}
+
 func (s *FeedItemService) Mutate(feedItemOperations FeedItemOperations) (feedItems []FeedItem, err error) {
+	return s.MutateContext(context.Background(), feedItemOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *FeedItemService) MutateContext(ctx context.Context, feedItemOperations FeedItemOperations) (feedItems []FeedItem, err error) {
 	type feedItemOperation struct {
 		Action   string   `xml:"operator"`
 		FeedItem FeedItem `xml:"operand"`
@@ -109,7 +151,7 @@ func (s *FeedItemService) Mutate(feedItemOperations FeedItemOperations) (feedIte
 		XMLName: xml.Name{Space: baseUrl, Local: "mutate"},
 		Ops:     operations,
 	}
-	respBody, err := s.Auth.request(feedItemServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, feedItemServiceUrl, "mutate", mutation)
 	if err != nil {
 		return
 	}
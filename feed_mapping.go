@@ -1,5 +1,11 @@
 package gads
 
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+)
+
 type FeedMappingService struct {
 	Auth
 }
@@ -7,3 +13,333 @@ type FeedMappingService struct {
 func NewFeedMappingService(auth *Auth) *FeedMappingService {
 	return &FeedMappingService{Auth: *auth}
 }
+
+// Placeholder types a feed is mapped to
+//
+// see https://developers.google.com/adwords/api/docs/appendix/placeholders
+const (
+	PlaceholderTypeSitelink          = 1
+	PlaceholderTypeCall              = 2
+	PlaceholderTypeApp               = 3
+	PlaceholderTypeLocation          = 7
+	PlaceholderTypeAdCustomizer      = 10
+	PlaceholderTypeCallout           = 17
+	PlaceholderTypeReview            = 18
+	PlaceholderTypeStructuredSnippet = 24
+	PlaceholderTypeAffiliateLocation = 30
+	PlaceholderTypeMessage           = 31
+	PlaceholderTypePrice             = 35
+	PlaceholderTypePromotion         = 38
+)
+
+// Placeholder fields of the sitelink placeholder type
+const (
+	SitelinkPlaceholderLinkText        = 1
+	SitelinkPlaceholderLinkUrl         = 2
+	SitelinkPlaceholderLine2           = 3
+	SitelinkPlaceholderLine3           = 4
+	SitelinkPlaceholderFinalUrls       = 5
+	SitelinkPlaceholderFinalMobileUrls = 6
+	SitelinkPlaceholderTrackingUrl     = 7
+	SitelinkPlaceholderFinalUrlSuffix  = 8
+)
+
+// Placeholder fields of the callout placeholder type
+const (
+	CalloutPlaceholderCalloutText = 1
+)
+
+// Placeholder fields of the price placeholder type, the fields of its
+// items are given by PriceItemPlaceholder.
+const (
+	PricePlaceholderType             = 2
+	PricePlaceholderPriceQualifier   = 3
+	PricePlaceholderTrackingTemplate = 4
+	PricePlaceholderLanguage         = 5
+	PricePlaceholderFinalUrlSuffix   = 6
+)
+
+// Fields of the items of the price placeholder type, see
+// PriceItemPlaceholder.
+const (
+	PriceItemHeader          = 0
+	PriceItemDescription     = 1
+	PriceItemPrice           = 2
+	PriceItemUnit            = 3
+	PriceItemFinalUrls       = 4
+	PriceItemFinalMobileUrls = 5
+)
+
+// PriceItemPlaceholder returns the placeholder field of field, like
+// PriceItemHeader, for the item-th item of a price extension, between 1
+// and 8.
+func PriceItemPlaceholder(item int, field int64) int64 {
+	return int64(item)*100 + field
+}
+
+// Placeholder fields of the ad customizer placeholder type
+const (
+	AdCustomizerPlaceholderInteger = 1
+	AdCustomizerPlaceholderPrice   = 2
+	AdCustomizerPlaceholderDate    = 3
+	AdCustomizerPlaceholderString  = 4
+)
+
+// AttributeFieldMapping maps an attribute of a feed to a field of a
+// placeholder type.
+type AttributeFieldMapping struct {
+	FeedAttributeId int64 `xml:"feedAttributeId"`
+	FieldId         int64 `xml:"fieldId"`
+}
+
+// FeedMapping maps the attributes of a feed to the fields of a
+// placeholder type, or of a criterion type for page feeds.
+type FeedMapping struct {
+	Id                     int64                   `xml:"feedMappingId,omitempty"`
+	FeedId                 int64                   `xml:"feedId"`
+	PlaceholderType        int64                   `xml:"placeholderType,omitempty"`
+	Status                 string                  `xml:"status,omitempty"` // Status: "ENABLED", "REMOVED"
+	AttributeFieldMappings []AttributeFieldMapping `xml:"attributeFieldMappings,omitempty"`
+	CriterionType          int64                   `xml:"criterionType,omitempty"`
+}
+
+// NewFeedMapping maps the attributes of feed to the fields of
+// placeholderType, fields giving the placeholder field of each attribute
+// name. The feed must have been created so that its attributes have an
+// id.
+//
+// Example
+//
+//   mapping, err := gads.NewFeedMapping(feed, gads.PlaceholderTypeSitelink, map[string]int64{
+//     "Link Text":  gads.SitelinkPlaceholderLinkText,
+//     "Final URLs": gads.SitelinkPlaceholderFinalUrls,
+//   })
+//
+func NewFeedMapping(feed Feed, placeholderType int64, fields map[string]int64) (FeedMapping, error) {
+	mapping := FeedMapping{
+		FeedId:          feed.Id,
+		PlaceholderType: placeholderType,
+	}
+	for _, attribute := range feed.Attributes {
+		fieldId, ok := fields[attribute.Name]
+		if !ok {
+			continue
+		}
+		if attribute.Id == 0 {
+			return mapping, fmt.Errorf("gads: attribute %q of feed %d has no id", attribute.Name, feed.Id)
+		}
+		mapping.AttributeFieldMappings = append(mapping.AttributeFieldMappings, AttributeFieldMapping{
+			FeedAttributeId: attribute.Id,
+			FieldId:         fieldId,
+		})
+	}
+	if len(mapping.AttributeFieldMappings) != len(fields) {
+		for name := range fields {
+			if _, ok := feed.Attribute(name); !ok {
+				return mapping, fmt.Errorf("gads: feed %d has no attribute %q", feed.Id, name)
+			}
+		}
+	}
+	return mapping, nil
+}
+
+// FeedMappingOperations is a map of operations to perform on FeedMapping's
+type FeedMappingOperations map[string][]FeedMapping
+
+// FeedMappingFields is the catalog of the fields accepted by the selectors
+// of FeedMappingService.Get, see NewSelector.
+var FeedMappingFields = FieldCatalog{
+	Service: "FeedMappingService",
+	Selectable: []string{
+		"AttributeFieldMappings", "CriterionType", "FeedId", "FeedMappingId",
+		"PlaceholderType", "Status",
+	},
+	Filterable: []string{
+		"CriterionType", "FeedId", "FeedMappingId", "PlaceholderType",
+		"Status",
+	},
+	Complete: true,
+}
+
+// Get returns an array of FeedMapping's and the total number of
+// FeedMapping's matching the selector.
+//
+// Example
+//
+//   feedMappings, totalCount, err := feedMappingService.Get(
+//     Selector{
+//       Fields: []string{"FeedMappingId","FeedId","PlaceholderType","AttributeFieldMappings"},
+//       Predicates: []Predicate{
+//         {"FeedId", "EQUALS", []string{"1234"}},
+//         {"Status", "EQUALS", []string{"ENABLED"}},
+//       },
+//     },
+//   )
+//
+// Selectable fields are
+//   "AttributeFieldMappings", "CriterionType", "FeedId", "FeedMappingId",
+//   "PlaceholderType", "Status"
+//
+// filterable fields are
+//   "CriterionType", "FeedId", "FeedMappingId", "PlaceholderType", "Status"
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/FeedMappingService#get
+//
+func (s *FeedMappingService) Get(selector Selector) (feedMappings []FeedMapping, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *FeedMappingService) GetContext(ctx context.Context, selector Selector) (feedMappings []FeedMapping, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		feedMappingServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return feedMappings, totalCount, err
+	}
+	getResp := struct {
+		Size         int64         `xml:"rval>totalNumEntries"`
+		FeedMappings []FeedMapping `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return feedMappings, totalCount, err
+	}
+	return getResp.FeedMappings, getResp.Size, err
+}
+
+// Mutate allows you to add and remove feed mappings, returning the
+// modified feed mappings. A feed mapping can't be modified, it has to be
+// removed and added again.
+//
+// Example
+//
+//  feedMappings, err := feedMappingService.Mutate(
+//    FeedMappingOperations{
+//      "ADD": {
+//        FeedMapping{
+//          FeedId:          1234,
+//          PlaceholderType: PlaceholderTypeCallout,
+//          AttributeFieldMappings: []AttributeFieldMapping{
+//            {FeedAttributeId: 1, FieldId: CalloutPlaceholderCalloutText},
+//          },
+//        },
+//      },
+//      "REMOVE": {
+//        FeedMapping{Id: 10, FeedId: 1234},
+//      },
+//    },
+//  )
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/FeedMappingService#mutate
+//
+func (s *FeedMappingService) Mutate(feedMappingOperations FeedMappingOperations) (feedMappings []FeedMapping, err error) {
+	return s.MutateContext(context.Background(), feedMappingOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *FeedMappingService) MutateContext(ctx context.Context, feedMappingOperations FeedMappingOperations) (feedMappings []FeedMapping, err error) {
+	type feedMappingOperation struct {
+		Action      string      `xml:"operator"`
+		FeedMapping FeedMapping `xml:"operand"`
+	}
+	operations := []feedMappingOperation{}
+	for action, feedMappings := range feedMappingOperations {
+		for _, feedMapping := range feedMappings {
+			operations = append(operations,
+				feedMappingOperation{
+					Action:      action,
+					FeedMapping: feedMapping,
+				},
+			)
+		}
+	}
+	mutation := struct {
+		XMLName xml.Name
+		Ops     []feedMappingOperation `xml:"operations"`
+	}{
+		XMLName: xml.Name{
+			Space: baseUrl,
+			Local: "mutate",
+		},
+		Ops: operations,
+	}
+	respBody, err := s.Auth.request(ctx, feedMappingServiceUrl, "mutate", mutation)
+	if err != nil {
+		return feedMappings, err
+	}
+	mutateResp := struct {
+		BaseResponse
+		FeedMappings []FeedMapping `xml:"rval>value"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &mutateResp)
+	if err != nil {
+		return feedMappings, err
+	}
+
+	if len(mutateResp.PartialFailureErrors) > 0 {
+		err = mutateResp.PartialFailureErrors
+	}
+
+	return mutateResp.FeedMappings, err
+}
+
+// Query returns the feed mappings matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   feedMappings, totalCount, err := feedMappingService.Query("SELECT FeedMappingId, AttributeFieldMappings WHERE FeedId = 1234")
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/FeedMappingService#query
+//
+func (s *FeedMappingService) Query(query string) (feedMappings []FeedMapping, totalCount int64, err error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *FeedMappingService) QueryContext(ctx context.Context, query string) (feedMappings []FeedMapping, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		feedMappingServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return feedMappings, totalCount, err
+	}
+	queryResp := struct {
+		Size         int64         `xml:"rval>totalNumEntries"`
+		FeedMappings []FeedMapping `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return feedMappings, totalCount, err
+	}
+	return queryResp.FeedMappings, queryResp.Size, err
+}
@@ -0,0 +1,152 @@
+package gads
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testFeedGetResponse = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <getResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809">
+      <rval>
+        <totalNumEntries>2</totalNumEntries>
+        <entries>
+          <id>11</id><name>sitelinks</name>
+          <attributes><id>1</id><name>Link Text</name><type>STRING</type></attributes>
+          <attributes><id>2</id><name>Final URLs</name><type>URL_LIST</type></attributes>
+          <status>ENABLED</status><origin>USER</origin>
+        </entries>
+        <entries>
+          <id>12</id><name>locations</name><status>ENABLED</status><origin>ADWORDS</origin>
+          <systemFeedGenerationData xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="PlacesLocationFeedData">
+            <emailAddress>owner@example.com</emailAddress>
+            <labelFilters>paris</labelFilters>
+          </systemFeedGenerationData>
+        </entries>
+      </rval>
+    </getResponse>
+  </soap:Body>
+</soap:Envelope>`
+
+func TestFeedService(t *testing.T) {
+	var sent string
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		sent = string(body)
+		fmt.Fprint(w, testFeedGetResponse)
+	})
+
+	feeds, totalCount, err := NewFeedService(&auth).Get(Selector{Fields: FeedFields.Selectable})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if totalCount != 2 || len(feeds) != 2 {
+		t.Fatalf("unexpected feeds %+v", feeds)
+	}
+	if a, ok := feeds[0].Attribute("Final URLs"); !ok || a.Id != 2 || a.Type != FeedAttributeTypeUrlList {
+		t.Errorf("unexpected attributes %+v", feeds[0].Attributes)
+	}
+	data := feeds[1].SystemFeedGenerationData
+	if data == nil || data.Type != "PlacesLocationFeedData" || data.EmailAddress != "owner@example.com" || len(data.LabelFilters) != 1 {
+		t.Errorf("unexpected system feed generation data %+v", data)
+	}
+	if !strings.Contains(sent, "<fields>SystemFeedGenerationData</fields>") {
+		t.Errorf("selector not sent as expected: %s", sent)
+	}
+
+	mapping, err := NewFeedMapping(feeds[0], PlaceholderTypeSitelink, map[string]int64{
+		"Link Text":  SitelinkPlaceholderLinkText,
+		"Final URLs": SitelinkPlaceholderFinalUrls,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []AttributeFieldMapping{{1, SitelinkPlaceholderLinkText}, {2, SitelinkPlaceholderFinalUrls}}
+	if mapping.FeedId != 11 || mapping.PlaceholderType != PlaceholderTypeSitelink || fmt.Sprint(mapping.AttributeFieldMappings) != fmt.Sprint(want) {
+		t.Errorf("unexpected mapping %+v", mapping)
+	}
+	// the elements follow the sequence of the wsdl
+	b, err := xml.Marshal(mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "<FeedMapping><feedId>11</feedId><placeholderType>1</placeholderType><attributeFieldMappings>"; !strings.HasPrefix(string(b), want) {
+		t.Errorf("unexpected mapping xml %s", b)
+	}
+	if _, err := NewFeedMapping(feeds[0], PlaceholderTypeSitelink, map[string]int64{"Line 2": SitelinkPlaceholderLine2}); err == nil {
+		t.Error("expected an error for a missing attribute")
+	}
+	if PriceItemPlaceholder(3, PriceItemFinalUrls) != 304 {
+		t.Errorf("unexpected price item placeholder %d", PriceItemPlaceholder(3, PriceItemFinalUrls))
+	}
+}
+
+func TestFeedMatchingFunction(t *testing.T) {
+	var sent string
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		sent = string(body)
+		fmt.Fprint(w, `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>
+<mutateResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><rval><value>
+  <feedId>11</feedId><adGroupId>22</adGroupId>
+  <matchingFunction>
+    <operator>AND</operator>
+    <lhsOperand xsi:type="FunctionOperand"><value>
+      <operator>IN</operator>
+      <lhsOperand xsi:type="RequestContextOperand"><contextType>FEED_ITEM_ID</contextType></lhsOperand>
+      <rhsOperand xsi:type="ConstantOperand"><type>LONG</type><longValue>1000001</longValue></rhsOperand>
+    </value></lhsOperand>
+    <functionString>AND(IN(FEED_ITEM_ID,{1000001}))</functionString>
+  </matchingFunction>
+  <placeholderTypes>17</placeholderTypes><status>ENABLED</status>
+</value></rval></mutateResponse></soap:Body></soap:Envelope>`)
+	})
+
+	adGroupFeeds, err := NewAdGroupFeedService(&auth).Mutate(AdGroupFeedOperations{
+		"ADD": {{
+			FeedId:           11,
+			AdGroupId:        22,
+			MatchingFunction: NewAndFunction(NewFeedItemIdFunction(1000001), NewDevicePlatformFunction("Mobile")),
+			PlaceholderTypes: []int64{PlaceholderTypeCallout},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<operator>AND</operator>`,
+		`type="FunctionOperand"`,
+		`type="RequestContextOperand"`,
+		`<contextType>FEED_ITEM_ID</contextType>`,
+		`type="ConstantOperand"`,
+		`<longValue>1000001</longValue>`,
+		`<contextType>DEVICE_PLATFORM</contextType>`,
+		`<stringValue>Mobile</stringValue>`,
+		`<placeholderTypes>17</placeholderTypes>`,
+	} {
+		if !strings.Contains(sent, want) {
+			t.Errorf("%s not sent in %s", want, sent)
+		}
+	}
+
+	f := adGroupFeeds[0].MatchingFunction
+	if len(adGroupFeeds) != 1 || f.FunctionString != "AND(IN(FEED_ITEM_ID,{1000001}))" || len(f.LhsOperand) != 1 {
+		t.Fatalf("unexpected ad group feeds %+v", adGroupFeeds)
+	}
+	in := f.LhsOperand[0].Value
+	if in == nil || in.LhsOperand[0].ContextType != RequestContextFeedItemId || *in.RhsOperand[0].LongValue != 1000001 {
+		t.Errorf("unexpected nested function %+v", in)
+	}
+
+	// removals don't send a matching function
+	if _, err := NewCustomerFeedService(&auth).Mutate(CustomerFeedOperations{"REMOVE": {{FeedId: 11}}}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(sent, "matchingFunction") {
+		t.Errorf("empty matching function sent: %s", sent)
+	}
+}
@@ -0,0 +1,165 @@
+package gads
+
+import "encoding/xml"
+
+// Function operators
+const (
+	FunctionOperatorIn          = "IN"
+	FunctionOperatorIdentity    = "IDENTITY"
+	FunctionOperatorEquals      = "EQUALS"
+	FunctionOperatorAnd         = "AND"
+	FunctionOperatorContainsAny = "CONTAINS_ANY"
+)
+
+// Request context types of a RequestContextOperand
+const (
+	RequestContextFeedItemId     = "FEED_ITEM_ID"
+	RequestContextDevicePlatform = "DEVICE_PLATFORM"
+)
+
+// Function is the matching function selecting the feed items a customer,
+// campaign or ad group feed serves, like
+//
+//   IN(FEED_ITEM_ID,{1000001,1000002})
+//
+// Either Operator and the operands or FunctionString are set, the api
+// returns both.
+//
+// see https://developers.google.com/adwords/api/docs/guides/feed-matching-functions
+type Function struct {
+	Operator       string                    `xml:"operator,omitempty"` // Operator: "IN", "IDENTITY", "EQUALS", "AND", "CONTAINS_ANY"
+	LhsOperand     []FunctionArgumentOperand `xml:"lhsOperand,omitempty"`
+	RhsOperand     []FunctionArgumentOperand `xml:"rhsOperand,omitempty"`
+	FunctionString string                    `xml:"functionString,omitempty"`
+}
+
+// MarshalXML omits empty functions, like the matching function of a
+// removal.
+func (f Function) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if f.Operator == "" && f.FunctionString == "" && len(f.LhsOperand) == 0 && len(f.RhsOperand) == 0 {
+		return nil
+	}
+	type function Function
+	return e.EncodeElement(function(f), start)
+}
+
+// FunctionArgumentOperand is an operand of a Function. Type is one of
+// ConstantOperand, FeedAttributeOperand, FunctionOperand or
+// RequestContextOperand, only the fields of that type are used. When
+// empty it is guessed from the fields set, see the New*Operand functions.
+type FunctionArgumentOperand struct {
+	Type string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+
+	// ConstantOperand
+	ConstantType string   `xml:"type,omitempty"` // ConstantType: "BOOLEAN", "DOUBLE", "LONG", "STRING"
+	Unit         string   `xml:"unit,omitempty"` // Unit: "METERS", "MILES", "MINOR_UNITS"
+	LongValue    *int64   `xml:"longValue,omitempty"`
+	BooleanValue *bool    `xml:"booleanValue,omitempty"`
+	DoubleValue  *float64 `xml:"doubleValue,omitempty"`
+	StringValue  *string  `xml:"stringValue,omitempty"`
+
+	// FeedAttributeOperand
+	FeedId          int64 `xml:"feedId,omitempty"`
+	FeedAttributeId int64 `xml:"feedAttributeId,omitempty"`
+
+	// FunctionOperand
+	Value *Function `xml:"value,omitempty"`
+
+	// RequestContextOperand
+	ContextType string `xml:"contextType,omitempty"` // ContextType: "FEED_ITEM_ID", "DEVICE_PLATFORM"
+}
+
+// GetType returns the xsi type of the operand according to its fields.
+func (o FunctionArgumentOperand) GetType() string {
+	switch {
+	case o.Type != "":
+		return o.Type
+	case o.ContextType != "":
+		return "RequestContextOperand"
+	case o.Value != nil:
+		return "FunctionOperand"
+	case o.FeedAttributeId != 0:
+		return "FeedAttributeOperand"
+	}
+	return "ConstantOperand"
+}
+
+// NewLongOperand returns a LONG constant operand.
+func NewLongOperand(value int64) FunctionArgumentOperand {
+	return FunctionArgumentOperand{Type: "ConstantOperand", ConstantType: "LONG", LongValue: &value}
+}
+
+// NewStringOperand returns a STRING constant operand.
+func NewStringOperand(value string) FunctionArgumentOperand {
+	return FunctionArgumentOperand{Type: "ConstantOperand", ConstantType: "STRING", StringValue: &value}
+}
+
+// NewBooleanOperand returns a BOOLEAN constant operand.
+func NewBooleanOperand(value bool) FunctionArgumentOperand {
+	return FunctionArgumentOperand{Type: "ConstantOperand", ConstantType: "BOOLEAN", BooleanValue: &value}
+}
+
+// NewDoubleOperand returns a DOUBLE constant operand.
+func NewDoubleOperand(value float64) FunctionArgumentOperand {
+	return FunctionArgumentOperand{Type: "ConstantOperand", ConstantType: "DOUBLE", DoubleValue: &value}
+}
+
+// NewFeedAttributeOperand returns an operand referring to the attribute
+// of a feed.
+func NewFeedAttributeOperand(feedId, feedAttributeId int64) FunctionArgumentOperand {
+	return FunctionArgumentOperand{Type: "FeedAttributeOperand", FeedId: feedId, FeedAttributeId: feedAttributeId}
+}
+
+// NewFunctionOperand returns an operand nesting function.
+func NewFunctionOperand(function Function) FunctionArgumentOperand {
+	return FunctionArgumentOperand{Type: "FunctionOperand", Value: &function}
+}
+
+// NewRequestContextOperand returns an operand for a value of the request
+// context, like RequestContextFeedItemId.
+func NewRequestContextOperand(contextType string) FunctionArgumentOperand {
+	return FunctionArgumentOperand{Type: "RequestContextOperand", ContextType: contextType}
+}
+
+// NewFeedItemIdFunction returns the function matching the feed items
+// having the given ids, IN(FEED_ITEM_ID,{ids}).
+func NewFeedItemIdFunction(feedItemIds ...int64) Function {
+	f := Function{
+		Operator:   FunctionOperatorIn,
+		LhsOperand: []FunctionArgumentOperand{NewRequestContextOperand(RequestContextFeedItemId)},
+	}
+	for _, id := range feedItemIds {
+		f.RhsOperand = append(f.RhsOperand, NewLongOperand(id))
+	}
+	return f
+}
+
+// NewIdentityFunction returns the function matching either all the feed
+// items or none, IDENTITY(true) or IDENTITY(false).
+func NewIdentityFunction(match bool) Function {
+	return Function{
+		Operator:   FunctionOperatorIdentity,
+		LhsOperand: []FunctionArgumentOperand{NewBooleanOperand(match)},
+	}
+}
+
+// NewDevicePlatformFunction returns the function matching the requests
+// from a device platform, "Mobile" or "Desktop",
+// EQUALS(CONTEXT.DEVICE,"Mobile").
+func NewDevicePlatformFunction(platform string) Function {
+	return Function{
+		Operator:   FunctionOperatorEquals,
+		LhsOperand: []FunctionArgumentOperand{NewRequestContextOperand(RequestContextDevicePlatform)},
+		RhsOperand: []FunctionArgumentOperand{NewStringOperand(platform)},
+	}
+}
+
+// NewAndFunction returns the function matching when all the functions
+// match.
+func NewAndFunction(functions ...Function) Function {
+	f := Function{Operator: FunctionOperatorAnd}
+	for _, function := range functions {
+		f.LhsOperand = append(f.LhsOperand, NewFunctionOperand(function))
+	}
+	return f
+}
@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -31,6 +32,18 @@ func NewTextLabel(name string) Label {
 // LabelOperations is a map of operations to perform on Label's
 type LabelOperations map[string][]Label
 
+// LabelFields is the catalog of the fields accepted by the selectors of
+// LabelService.Get, see NewSelector.
+var LabelFields = FieldCatalog{
+	Service: "LabelService",
+	Selectable: []string{
+		"LabelId", "LabelName", "LabelStatus",
+	},
+	Filterable: []string{
+		"LabelId", "LabelName", "LabelStatus",
+	},
+}
+
 // Get returns an array of Label's and the total number of Label's matching
 // the selector.
 //
@@ -56,8 +69,14 @@ type LabelOperations map[string][]Label
 //     https://developers.google.com/adwords/api/docs/reference/v201809/LabelService#get
 //
 func (s LabelService) Get(selector Selector) (labels []Label, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s LabelService) GetContext(ctx context.Context, selector Selector) (labels []Label, totalCount int64, err error) {
 	selector.XMLName = xml.Name{"", "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		labelServiceUrl,
 		"get",
 		struct {
@@ -110,6 +129,11 @@ func (s LabelService) Get(selector Selector) (labels []Label, totalCount int64,
 //     https://developers.google.com/adwords/api/docs/reference/v201809/LabelService#mutate
 //
 func (s *LabelService) Mutate(labelOperations LabelOperations) (labels []Label, err error) {
+	return s.MutateContext(context.Background(), labelOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *LabelService) MutateContext(ctx context.Context, labelOperations LabelOperations) (labels []Label, err error) {
 	type labelOperation struct {
 		Action string `xml:"operator"`
 		Label  Label  `xml:"operand"`
@@ -135,7 +159,7 @@ func (s *LabelService) Mutate(labelOperations LabelOperations) (labels []Label,
 		},
 		Ops: operations,
 	}
-	respBody, err := s.Auth.request(labelServiceUrl, "mutate", mutation)
+	respBody, err := s.Auth.request(ctx, labelServiceUrl, "mutate", mutation)
 	if err != nil {
 		return labels, err
 	}
@@ -155,12 +179,45 @@ func (s *LabelService) Mutate(labelOperations LabelOperations) (labels []Label,
 	return mutateResp.Labels, err
 }
 
-// Query is not yet implemented
+// Query returns the labels matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   labels, totalCount, err := labelService.Query("SELECT LabelId, LabelName WHERE LabelStatus = 'ENABLED'")
 //
 // Relevant documentation
 //
 //     https://developers.google.com/adwords/api/docs/reference/v201809/LabelService#query
 //
 func (s *LabelService) Query(query string) (labels []Label, totalCount int64, err error) {
-	return labels, totalCount, ERROR_NOT_YET_IMPLEMENTED
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *LabelService) QueryContext(ctx context.Context, query string) (labels []Label, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		labelServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return labels, totalCount, err
+	}
+	queryResp := struct {
+		Size   int64   `xml:"rval>totalNumEntries"`
+		Labels []Label `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return labels, totalCount, err
+	}
+	return queryResp.Labels, queryResp.Size, err
 }
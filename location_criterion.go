@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -22,8 +23,14 @@ type LocationCriterion struct {
 type LocationCriterions []LocationCriterion
 
 func (s *LocationCriterionService) Get(selector Selector) (locationCriterions LocationCriterions, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *LocationCriterionService) GetContext(ctx context.Context, selector Selector) (locationCriterions LocationCriterions, err error) {
 	selector.XMLName = xml.Name{"", "selector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		locationCriterionServiceUrl,
 		"get",
 		struct {
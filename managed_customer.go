@@ -1,6 +1,9 @@
 package gads
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 // ManagedCustomerService represents the api that handle links between accounts
 type ManagedCustomerService struct {
@@ -71,10 +74,21 @@ func (m *ManagedCustomerService) Get(selector Selector) (
 	managedCustomerLinks []ManagedCustomerLink,
 	totalCount int64,
 	err error,
+) {
+	return m.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (m *ManagedCustomerService) GetContext(ctx context.Context, selector Selector) (
+	customers []ManagedCustomer,
+	managedCustomerLinks []ManagedCustomerLink,
+	totalCount int64,
+	err error,
 ) {
 	selector.XMLName = xml.Name{"", "serviceSelector"}
 	var respBody []byte
 	respBody, err = m.Auth.request(
+		ctx,
 		managedCustomerServiceUrl,
 		"get",
 		struct {
@@ -111,6 +125,11 @@ func (m *ManagedCustomerService) Get(selector Selector) (
 
 // MutateManager takes a budgetOperations and creates, modifies or destroys the associated budgets.
 func (m *ManagedCustomerService) MutateManager(mcmOps ManagedCustomerMoveOperations) (links []ManagedCustomerLink, err error) {
+	return m.MutateManagerContext(context.Background(), mcmOps)
+}
+
+// MutateManagerContext is like MutateManager but binds the request to ctx.
+func (m *ManagedCustomerService) MutateManagerContext(ctx context.Context, mcmOps ManagedCustomerMoveOperations) (links []ManagedCustomerLink, err error) {
 	type managedCustomerMoveOperation struct {
 		Action               string              `xml:"https://adwords.google.com/api/adwords/cm/v201809 operator"`
 		Link                 ManagedCustomerLink `xml:"operand"`
@@ -131,6 +150,7 @@ func (m *ManagedCustomerService) MutateManager(mcmOps ManagedCustomerMoveOperati
 		}
 	}
 	respBody, err := m.Auth.request(
+		ctx,
 		managedCustomerServiceUrl,
 		"mutateManager",
 		struct {
@@ -165,6 +185,11 @@ func (m *ManagedCustomerService) MutateManager(mcmOps ManagedCustomerMoveOperati
 
 // MutateLink changes the links between mcc and classic adwords account
 func (m *ManagedCustomerService) MutateLink(mcl ManagedCustomerLinkOperations) ([]*ManagedCustomerLink, error) {
+	return m.MutateLinkContext(context.Background(), mcl)
+}
+
+// MutateLinkContext is like MutateLink but binds the request to ctx.
+func (m *ManagedCustomerService) MutateLinkContext(ctx context.Context, mcl ManagedCustomerLinkOperations) ([]*ManagedCustomerLink, error) {
 
 	type linkOperation struct {
 		Action string               `xml:"https://adwords.google.com/api/adwords/cm/v201809 operator"`
@@ -184,6 +209,7 @@ func (m *ManagedCustomerService) MutateLink(mcl ManagedCustomerLinkOperations) (
 		}
 	}
 	respBody, err := m.Auth.request(
+		ctx,
 		managedCustomerServiceUrl,
 		"mutateLink",
 		struct {
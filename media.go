@@ -1,6 +1,7 @@
 package gads
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/xml"
 )
@@ -74,8 +75,14 @@ func NewVideo(mediaType string) (image Media) {
 }
 
 func (s *MediaService) Get(selector Selector) (medias []Media, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *MediaService) GetContext(ctx context.Context, selector Selector) (medias []Media, totalCount int64, err error) {
 	selector.XMLName = xml.Name{"", "serviceSelector"}
 	respBody, err := s.Auth.request(
+		ctx,
 		mediaServiceUrl,
 		"get",
 		struct {
@@ -103,11 +110,55 @@ func (s *MediaService) Get(selector Selector) (medias []Media, totalCount int64,
 	return getResp.Medias, getResp.Size, err
 }
 
+// Query returns the medias matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   medias, totalCount, err := mediaService.Query("SELECT MediaId, Name WHERE Type = 'IMAGE'")
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/MediaService#query
+//
 func (s *MediaService) Query(query string) (medias []Media, totalCount int64, err error) {
-	return medias, totalCount, ERROR_NOT_YET_IMPLEMENTED
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *MediaService) QueryContext(ctx context.Context, query string) (medias []Media, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		mediaServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return medias, totalCount, err
+	}
+	queryResp := struct {
+		Size   int64   `xml:"rval>totalNumEntries"`
+		Medias []Media `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return medias, totalCount, err
+	}
+	return queryResp.Medias, queryResp.Size, err
 }
 
 func (s *MediaService) Upload(medias []Media) (uploadedMedias []Media, err error) {
+	return s.UploadContext(context.Background(), medias)
+}
+
+// UploadContext is like Upload but binds the request to ctx.
+func (s *MediaService) UploadContext(ctx context.Context, medias []Media) (uploadedMedias []Media, err error) {
 	upload := struct {
 		XMLName xml.Name
 		Medias  []Media `xml:"media"`
@@ -118,7 +169,7 @@ func (s *MediaService) Upload(medias []Media) (uploadedMedias []Media, err error
 		},
 		Medias: medias,
 	}
-	respBody, err := s.Auth.request(mediaServiceUrl, "upload", upload)
+	respBody, err := s.Auth.request(ctx, mediaServiceUrl, "upload", upload)
 	if err != nil {
 		return uploadedMedias, err
 	}
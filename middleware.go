@@ -0,0 +1,145 @@
+package gads
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// SoapCall is a single soap round trip as seen by the middlewares. The
+// request fields are set before the call is handed to the chain, the
+// response fields once the innermost handler returns.
+type SoapCall struct {
+	Service    ServiceUrl
+	Action     string
+	CustomerId string
+	Operations int         // the operations of a mutate, 1 for other calls
+	Request    []byte      // the soap envelope sent
+	HTTPHeader http.Header // the http headers sent
+
+	StatusCode     int
+	Response       []byte // the raw body received, nil for streamed calls
+	ResponseHeader ResponseHeader
+
+	// the content of the soap body
+	body []byte
+	// stream decodes the response element of streamed calls straight
+	// from the http body, streamed is set once it has been called
+	stream   func(dec *xml.Decoder, start xml.StartElement) error
+	streamed bool
+}
+
+// SoapHandler performs a soap call.
+type SoapHandler func(ctx context.Context, call *SoapCall) error
+
+// Middleware wraps a SoapHandler to observe or alter the calls made by
+// every service. Retried calls go through the middlewares on every
+// attempt.
+//
+//   auth.Middlewares = append(auth.Middlewares, func(next gads.SoapHandler) gads.SoapHandler {
+//     return func(ctx context.Context, call *gads.SoapCall) error {
+//       err := next(ctx, call)
+//       metrics.Count(call.Service.Name, call.Action, err)
+//       return err
+//     }
+//   })
+type Middleware func(next SoapHandler) SoapHandler
+
+// handler chains the middlewares of a around send.
+func (a *Auth) handler() SoapHandler {
+	h := a.send
+	for i := len(a.Middlewares) - 1; i >= 0; i-- {
+		h = a.Middlewares[i](h)
+	}
+	return h
+}
+
+// ReportCall is a report download, through ReportDefinitionService or
+// AWQLClient, as seen by the report middlewares.
+type ReportCall struct {
+	CustomerId string
+	ReportType string // empty for AWQL downloads
+	Query      string // empty for report definitions
+	Format     string
+
+	StatusCode int
+}
+
+// ReportHandler performs a report download and returns the report body.
+type ReportHandler func(ctx context.Context, call *ReportCall) (io.ReadCloser, error)
+
+// ReportMiddleware wraps a ReportHandler like Middleware does for soap
+// calls.
+type ReportMiddleware func(next ReportHandler) ReportHandler
+
+// downloadReport chains the report middlewares of a around send.
+func (a *Auth) downloadReport(ctx context.Context, call *ReportCall, send ReportHandler) (io.ReadCloser, error) {
+	h := send
+	for i := len(a.ReportMiddlewares) - 1; i >= 0; i-- {
+		h = a.ReportMiddlewares[i](h)
+	}
+	return h(ctx, call)
+}
+
+var (
+	developerTokenRedaction = regexp.MustCompile(`(<(?:\w+:)?developerToken>)[^<]*(</(?:\w+:)?developerToken>)`)
+	redactedHTTPHeaders     = []string{"Authorization", "Developertoken"}
+)
+
+// RedactEnvelope hides the developer token of a soap envelope.
+func RedactEnvelope(envelope []byte) []byte {
+	return developerTokenRedaction.ReplaceAll(envelope, []byte("${1}REDACTED${2}"))
+}
+
+// RedactHTTPHeader returns a copy of h with the credentials hidden.
+func RedactHTTPHeader(h http.Header) http.Header {
+	r := h.Clone()
+	for _, k := range redactedHTTPHeaders {
+		if _, ok := r[k]; ok {
+			r[k] = []string{"REDACTED"}
+		}
+	}
+	return r
+}
+
+// NewLogMiddleware returns a Middleware logging every soap call to
+// logger. Failed calls are logged at the error level, the others at the
+// info level. The envelopes, with the developer token and the oauth
+// headers redacted, are only logged when the debug level is enabled.
+func NewLogMiddleware(logger *slog.Logger) Middleware {
+	return func(next SoapHandler) SoapHandler {
+		return func(ctx context.Context, call *SoapCall) error {
+			start := time.Now()
+			err := next(ctx, call)
+
+			level := slog.LevelInfo
+			if err != nil {
+				level = slog.LevelError
+			}
+			attrs := []slog.Attr{
+				slog.String("service", call.Service.Name),
+				slog.String("action", call.Action),
+				slog.Int("status", call.StatusCode),
+				slog.String("request_id", call.ResponseHeader.RequestId),
+				slog.Int64("operations", call.ResponseHeader.Operations),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+			if logger.Enabled(ctx, slog.LevelDebug) {
+				attrs = append(attrs,
+					slog.Any("http_header", RedactHTTPHeader(call.HTTPHeader)),
+					slog.String("request", string(RedactEnvelope(call.Request))),
+					slog.String("response", string(call.Response)),
+				)
+			}
+			logger.LogAttrs(ctx, level, "adwords soap call", attrs...)
+			return err
+		}
+	}
+}
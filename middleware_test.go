@@ -0,0 +1,60 @@
+package gads
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewares(t *testing.T) {
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testEmptyGetResponse)
+	})
+	order := []string{}
+	trace := func(name string) Middleware {
+		return func(next SoapHandler) SoapHandler {
+			return func(ctx context.Context, call *SoapCall) error {
+				order = append(order, name+" in")
+				err := next(ctx, call)
+				order = append(order, name+" out "+call.ResponseHeader.RequestId)
+				return err
+			}
+		}
+	}
+	auth.Middlewares = []Middleware{trace("a"), trace("b")}
+
+	if _, _, err := NewCampaignService(&auth).Get(Selector{Fields: []string{"Id"}}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "a in,b in,b out 0005a1b2c3d5,a out 0005a1b2c3d5"
+	if got := strings.Join(order, ","); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestLogMiddleware(t *testing.T) {
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testEmptyGetResponse)
+	})
+	auth.DeveloperToken = "very-secret-token"
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	auth.Middlewares = []Middleware{NewLogMiddleware(logger)}
+
+	if _, _, err := NewCampaignService(&auth).Get(Selector{Fields: []string{"Id"}}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "very-secret-token") {
+		t.Errorf("developer token leaked in the logs:\n%s", out)
+	}
+	for _, expected := range []string{"service=CampaignService", "action=get", "request_id=0005a1b2c3d5", "REDACTED"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in the logs:\n%s", expected, out)
+		}
+	}
+}
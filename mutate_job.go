@@ -1,9 +1,859 @@
 package gads
 
-type MutateJobService struct {
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+const (
+	// every request of an incremental upload but the last one must be a
+	// multiple of batchJobChunkSize bytes
+	batchJobChunkSize = 256 * 1024
+)
+
+// Batch job statuses
+const (
+	BatchJobStatusAwaitingFile = "AWAITING_FILE"
+	BatchJobStatusActive       = "ACTIVE"
+	BatchJobStatusCanceling    = "CANCELING"
+	BatchJobStatusCanceled     = "CANCELED"
+	BatchJobStatusDone         = "DONE"
+)
+
+// BatchJobService runs large sets of mutate operations asynchronously.
+//
+// A batch job is created with Mutate, its operations are uploaded to its
+// upload url with NewUpload, then the job is polled with Wait until it is
+// done and its results are downloaded with Results. Run chains all of
+// these steps.
+type BatchJobService struct {
 	Auth
 }
 
+func NewBatchJobService(auth *Auth) *BatchJobService {
+	return &BatchJobService{Auth: *auth}
+}
+
+// MutateJobService is the former name of BatchJobService.
+//
+// Deprecated: use BatchJobService.
+type MutateJobService = BatchJobService
+
+// NewMutateJobService returns a BatchJobService.
+//
+// Deprecated: use NewBatchJobService.
 func NewMutateJobService(auth *Auth) *MutateJobService {
-	return &MutateJobService{Auth: *auth}
+	return NewBatchJobService(auth)
+}
+
+// TemporaryUrl is an url valid until its expiration, a date time like
+// "20190101 120000 Europe/Paris".
+type TemporaryUrl struct {
+	Url        string `xml:"url"`
+	Expiration string `xml:"expiration"`
+}
+
+// BatchJobProgressStats tells how far a batch job went.
+type BatchJobProgressStats struct {
+	NumOperationsExecuted    int64 `xml:"numOperationsExecuted"`
+	NumOperationsSucceeded   int64 `xml:"numOperationsSucceeded"`
+	EstimatedPercentExecuted int   `xml:"estimatedPercentExecuted"`
+	NumResultsWritten        int64 `xml:"numResultsWritten"`
+}
+
+// BatchJobProcessingError is an error preventing a batch job from
+// processing its upload, like a malformed file.
+type BatchJobProcessingError struct {
+	FieldPath   string `xml:"fieldPath"`
+	Trigger     string `xml:"trigger"`
+	ErrorString string `xml:"errorString"`
+	Reason      string `xml:"reason"`
+}
+
+func (e BatchJobProcessingError) Error() string {
+	return CommonApiError{
+		FieldPath:    e.FieldPath,
+		Trigger:      e.Trigger,
+		ErrorString:  e.ErrorString,
+		Reason:       e.Reason,
+		ApiErrorType: "BatchJobProcessingError",
+	}.Error()
+}
+
+// BatchJob represents a batch job.
+type BatchJob struct {
+	Id                    int64                     `xml:"id,omitempty"`
+	Status                string                    `xml:"status,omitempty"`
+	ProgressStats         *BatchJobProgressStats    `xml:"progressStats,omitempty"`
+	UploadUrl             *TemporaryUrl             `xml:"uploadUrl,omitempty"`
+	DownloadUrl           *TemporaryUrl             `xml:"downloadUrl,omitempty"`
+	ProcessingErrors      []BatchJobProcessingError `xml:"processingErrors,omitempty"`
+	DiskUsageQuotaBalance int64                     `xml:"diskUsageQuotaBalance,omitempty"`
+}
+
+// processingError joins the processing errors of the job to
+// ErrBatchJobProcessing.
+func (job BatchJob) processingError() error {
+	errs := []error{ErrBatchJobProcessing}
+	for _, e := range job.ProcessingErrors {
+		errs = append(errs, e)
+	}
+	return errors.Join(errs...)
+}
+
+// BatchJobOperations is a map of operations to perform on BatchJob's
+type BatchJobOperations map[string][]BatchJob
+
+// BatchJobFields is the catalog of the fields accepted by the selectors of
+// BatchJobService.Get, see NewSelector.
+var BatchJobFields = FieldCatalog{
+	Service: "BatchJobService",
+	Selectable: []string{
+		"DiskUsageQuotaBalance", "DownloadUrl", "Id", "ProcessingErrors",
+		"ProgressStats", "Status",
+	},
+	Filterable: []string{
+		"Id", "Status",
+	},
+	Complete: true,
+}
+
+// Get returns an array of BatchJob's and the total number of BatchJob's
+// matching the selector.
+//
+// Example
+//
+//   jobs, totalCount, err := batchJobService.Get(
+//     Selector{
+//       Fields: []string{"Id","Status","DownloadUrl","ProgressStats"},
+//       Predicates: []Predicate{
+//         {"Status", "IN", []string{"ACTIVE","AWAITING_FILE"}},
+//       },
+//     },
+//   )
+//
+// Selectable fields are
+//   "DiskUsageQuotaBalance", "DownloadUrl", "Id", "ProcessingErrors",
+//   "ProgressStats", "Status"
+//
+// filterable fields are
+//   "Id", "Status"
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/BatchJobService#get
+//
+func (s *BatchJobService) Get(selector Selector) (jobs []BatchJob, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *BatchJobService) GetContext(ctx context.Context, selector Selector) (jobs []BatchJob, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		batchJobServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return jobs, totalCount, err
+	}
+	getResp := struct {
+		Size int64      `xml:"rval>totalNumEntries"`
+		Jobs []BatchJob `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return jobs, totalCount, err
+	}
+	return getResp.Jobs, getResp.Size, err
+}
+
+// Mutate creates batch jobs, or cancels them by setting their status to
+// CANCELING, returning the modified jobs.
+//
+// Example
+//
+//  jobs, err := batchJobService.Mutate(
+//    BatchJobOperations{
+//      "ADD": {
+//        BatchJob{},
+//      },
+//      "SET": {
+//        BatchJob{Id: 10, Status: BatchJobStatusCanceling},
+//      },
+//    },
+//  )
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/BatchJobService#mutate
+//
+func (s *BatchJobService) Mutate(batchJobOperations BatchJobOperations) (jobs []BatchJob, err error) {
+	return s.MutateContext(context.Background(), batchJobOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *BatchJobService) MutateContext(ctx context.Context, batchJobOperations BatchJobOperations) (jobs []BatchJob, err error) {
+	type batchJobOperation struct {
+		Action   string   `xml:"operator"`
+		BatchJob BatchJob `xml:"operand"`
+	}
+	operations := []batchJobOperation{}
+	for action, jobs := range batchJobOperations {
+		for _, job := range jobs {
+			operations = append(operations,
+				batchJobOperation{
+					Action:   action,
+					BatchJob: job,
+				},
+			)
+		}
+	}
+	mutation := struct {
+		XMLName xml.Name
+		Ops     []batchJobOperation `xml:"operations"`
+	}{
+		XMLName: xml.Name{
+			Space: baseUrl,
+			Local: "mutate",
+		},
+		Ops: operations,
+	}
+	respBody, err := s.Auth.request(ctx, batchJobServiceUrl, "mutate", mutation)
+	if err != nil {
+		return jobs, err
+	}
+	mutateResp := struct {
+		BaseResponse
+		Jobs []BatchJob `xml:"rval>value"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &mutateResp)
+	if err != nil {
+		return jobs, err
+	}
+
+	if len(mutateResp.PartialFailureErrors) > 0 {
+		err = mutateResp.PartialFailureErrors
+	}
+
+	return mutateResp.Jobs, err
+}
+
+// BatchJobOperation is an operation of any service uploaded to a batch
+// job, see NewBatchJobOperations.
+type BatchJobOperation struct {
+	// Type is the xsi type of the operation, like "CampaignOperation".
+	Type     string
+	Operator string
+	Operand  interface{}
+}
+
+func (op BatchJobOperation) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xsi:type"}, Value: op.Type})
+	return e.EncodeElement(
+		struct {
+			Operator string      `xml:"operator"`
+			Operand  interface{} `xml:"operand"`
+		}{
+			Operator: op.Operator,
+			// like in a soap body, every struct of the operand needs its
+			// xsi:type
+			Operand: addXSIType(op.Operand),
+		},
+		start,
+	)
+}
+
+// NewBatchJobOperations converts the operations of a service mutate to
+// batch job operations. operations is one of CampaignOperations,
+// CampaignLabelOperations, CampaignCriterionOperations, BudgetOperations,
+// AdGroupOperations, AdGroupLabelOperations, AdGroupCriterionOperations,
+// AdGroupCriterionLabelOperations, AdGroupAdOperations,
+// AdGroupAdLabelOperations or LabelOperations.
+//
+// The operands are cleaned up like the service Mutate does. Removals come
+// first, then additions, then updates, the results of a batch job being
+// mapped back to the operations by their index.
+//
+// Example
+//
+//   ops, err := gads.NewBatchJobOperations(gads.AdGroupCriterionOperations{
+//     "ADD": {
+//       gads.BiddableAdGroupCriterion{
+//         AdGroupId: 1234,
+//         Criterion: gads.KeywordCriterion{Text: "cheap flights", MatchType: "EXACT"},
+//       },
+//     },
+//   })
+//
+func NewBatchJobOperations(operations interface{}) ([]BatchJobOperation, error) {
+	var operationType string
+	operands := map[string][]interface{}{}
+	switch o := operations.(type) {
+	case CampaignOperations:
+		operationType = "CampaignOperation"
+		for operator, campaigns := range o {
+			for _, campaign := range campaigns {
+				operands[operator] = append(operands[operator], campaignOperand(campaign))
+			}
+		}
+	case CampaignLabelOperations:
+		operationType = "CampaignLabelOperation"
+		for operator, campaignLabels := range o {
+			for _, campaignLabel := range campaignLabels {
+				operands[operator] = append(operands[operator], campaignLabel)
+			}
+		}
+	case CampaignCriterionOperations:
+		operationType = "CampaignCriterionOperation"
+		for operator, campaignCriterions := range o {
+			operands[operator] = append(operands[operator], campaignCriterions...)
+		}
+	case BudgetOperations:
+		operationType = "BudgetOperation"
+		for operator, budgets := range o {
+			for _, budget := range budgets {
+				operands[operator] = append(operands[operator], budget)
+			}
+		}
+	case AdGroupOperations:
+		operationType = "AdGroupOperation"
+		for operator, adGroups := range o {
+			for _, adGroup := range adGroups {
+				operands[operator] = append(operands[operator], adGroupOperand(adGroup))
+			}
+		}
+	case AdGroupLabelOperations:
+		operationType = "AdGroupLabelOperation"
+		for operator, adGroupLabels := range o {
+			for _, adGroupLabel := range adGroupLabels {
+				operands[operator] = append(operands[operator], adGroupLabel)
+			}
+		}
+	case AdGroupCriterionOperations:
+		operationType = "AdGroupCriterionOperation"
+		for operator, adGroupCriterions := range o {
+			for _, adGroupCriterion := range adGroupCriterions {
+				operands[operator] = append(operands[operator], adGroupCriterionOperand(adGroupCriterion))
+			}
+		}
+	case AdGroupCriterionLabelOperations:
+		operationType = "AdGroupCriterionLabelOperation"
+		for operator, adGroupCriterionLabels := range o {
+			for _, adGroupCriterionLabel := range adGroupCriterionLabels {
+				operands[operator] = append(operands[operator], adGroupCriterionLabel)
+			}
+		}
+	case AdGroupAdOperations:
+		operationType = "AdGroupAdOperation"
+		for operator, adGroupAds := range o {
+			for _, adGroupAd := range adGroupAds {
+				operands[operator] = append(operands[operator], adGroupAd)
+			}
+		}
+	case AdGroupAdLabelOperations:
+		operationType = "AdGroupAdLabelOperation"
+		for operator, adGroupAdLabels := range o {
+			for _, adGroupAdLabel := range adGroupAdLabels {
+				operands[operator] = append(operands[operator], adGroupAdLabel)
+			}
+		}
+	case LabelOperations:
+		operationType = "LabelOperation"
+		for operator, labels := range o {
+			for _, label := range labels {
+				operands[operator] = append(operands[operator], label)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("gads: %T operations can't be run in a batch job", operations)
+	}
+
+	ops := []BatchJobOperation{}
+	for _, operator := range batchJobOperators(operands) {
+		for _, operand := range operands[operator] {
+			ops = append(ops, BatchJobOperation{
+				Type:     operationType,
+				Operator: operator,
+				Operand:  operand,
+			})
+		}
+	}
+	return ops, nil
+}
+
+// batchJobOperators returns the operators of operands, REMOVE, ADD and
+// SET first and any other one in alphabetical order.
+func batchJobOperators(operands map[string][]interface{}) []string {
+	rank := map[string]int{"REMOVE": 1, "ADD": 2, "SET": 3}
+	operators := []string{}
+	for operator := range operands {
+		operators = append(operators, operator)
+	}
+	sort.Slice(operators, func(i, j int) bool {
+		ri, rj := rank[operators[i]], rank[operators[j]]
+		if ri == 0 {
+			ri = len(rank) + 1
+		}
+		if rj == 0 {
+			rj = len(rank) + 1
+		}
+		if ri != rj {
+			return ri < rj
+		}
+		return operators[i] < operators[j]
+	})
+	return operators
+}
+
+// BatchJobUpload uploads the operations of a batch job incrementally,
+// following the resumable upload protocol of the upload url. Append may
+// be called several times, Close must be called once every operation
+// was appended for the job to start.
+type BatchJobUpload struct {
+	client *http.Client
+	// url is the resumable upload session
+	url        string
+	offset     int64
+	operations int
+	started    bool
+	closed     bool
+}
+
+// NewUpload starts the upload of the operations of job, which must have
+// an upload url.
+//
+// Example
+//
+//   upload, err := batchJobService.NewUpload(ctx, job)
+//   for _, ops := range chunks {
+//     if err := upload.Append(ctx, ops); err != nil {
+//       ...
+//     }
+//   }
+//   err = upload.Close(ctx)
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/guides/batch-jobs#incremental_uploads
+//
+func (s *BatchJobService) NewUpload(ctx context.Context, job BatchJob) (*BatchJobUpload, error) {
+	if job.UploadUrl == nil || job.UploadUrl.Url == "" {
+		return nil, fmt.Errorf("gads: batch job %d has no upload url", job.Id)
+	}
+	req, err := http.NewRequest("POST", job.UploadUrl.Url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("x-goog-resumable", "start")
+	resp, err := s.Auth.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return nil, errors.New("gads: batch job upload session without location")
+	}
+	return &BatchJobUpload{client: s.Auth.Client, url: location}, nil
+}
+
+// Operations returns the number of operations uploaded so far, the index
+// of the next appended operation.
+func (u *BatchJobUpload) Operations() int {
+	return u.operations
+}
+
+// Append uploads ops. The request is padded with white spaces to a
+// multiple of 256KiB, so operations should be appended by thousands
+// rather than one by one.
+func (u *BatchJobUpload) Append(ctx context.Context, ops []BatchJobOperation) error {
+	if u.closed {
+		return errors.New("gads: batch job upload already closed")
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := u.writeOperations(&buf, ops); err != nil {
+		return err
+	}
+	if pad := buf.Len() % batchJobChunkSize; pad != 0 {
+		buf.Write(bytes.Repeat([]byte(" "), batchJobChunkSize-pad))
+	}
+	if err := u.put(ctx, buf.Bytes(), false); err != nil {
+		return err
+	}
+	u.operations += len(ops)
+	return nil
+}
+
+// Close completes the upload, the batch job starts running once it is
+// closed.
+func (u *BatchJobUpload) Close(ctx context.Context) error {
+	if u.closed {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := u.writeOperations(&buf, nil); err != nil {
+		return err
+	}
+	buf.WriteString("</mutate>")
+	if err := u.put(ctx, buf.Bytes(), true); err != nil {
+		return err
+	}
+	u.closed = true
+	return nil
+}
+
+// writeOperations writes ops in buf, preceded by the opening mutate tag
+// for the first request of the upload.
+func (u *BatchJobUpload) writeOperations(buf *bytes.Buffer, ops []BatchJobOperation) error {
+	if !u.started {
+		buf.WriteString(xml.Header)
+		fmt.Fprintf(buf, `<mutate xmlns=%q xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">`, baseUrl)
+	}
+	enc := xml.NewEncoder(buf)
+	for _, op := range ops {
+		if err := enc.EncodeElement(op, xml.StartElement{Name: xml.Name{Local: "operations"}}); err != nil {
+			return err
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+	u.started = true
+	return nil
+}
+
+// put sends the next part of the upload, last telling the total size.
+func (u *BatchJobUpload) put(ctx context.Context, b []byte, last bool) error {
+	end := u.offset + int64(len(b))
+	total := "*"
+	if last {
+		total = strconv.FormatInt(end, 10)
+	}
+	req, err := http.NewRequest("PUT", u.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%s", u.offset, end-1, total))
+	resp, err := u.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// 308 Resume Incomplete acknowledges an intermediate part
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusPermanentRedirect {
+		return &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	u.offset = end
+	return nil
+}
+
+// Wait polls the batch job id until it is done or canceled, and returns
+// it. ErrBatchJobCanceled is returned along with canceled jobs, and
+// ErrBatchJobProcessing, joined with the ProcessingErrors of the job,
+// along with jobs done without results.
+func (s *BatchJobService) Wait(ctx context.Context, id int64, polling *Polling) (job BatchJob, err error) {
+	selector := Selector{
+		Fields: BatchJobFields.Selectable,
+		Predicates: []Predicate{
+			{"Id", "EQUALS", []string{strconv.FormatInt(id, 10)}},
+		},
+	}
+	for attempt := 1; ; attempt++ {
+		if err := polling.wait(ctx, attempt); err != nil {
+			return job, err
+		}
+
+		jobs, _, err := s.GetContext(ctx, selector)
+		if err != nil {
+			return job, err
+		}
+		if len(jobs) == 0 {
+			return job, fmt.Errorf("gads: batch job %d not found", id)
+		}
+		job = jobs[0]
+		switch job.Status {
+		case BatchJobStatusDone:
+			if len(job.ProcessingErrors) > 0 && (job.DownloadUrl == nil || job.DownloadUrl.Url == "") {
+				return job, job.processingError()
+			}
+			return job, nil
+		case BatchJobStatusCanceled:
+			return job, ErrBatchJobCanceled
+		}
+	}
+}
+
+// BatchJobResult is the result of an operation of a batch job.
+type BatchJobResult struct {
+	// Index is the index of the operation in the upload.
+	Index int64
+	// Operation is the operation at Index, when the operations were
+	// given to Results.
+	Operation *BatchJobOperation
+	// Result is the entity returned by a successful operation, a
+	// Campaign, an AdGroup, a Label, ... by value. Criteria are
+	// decoded like AdGroupCriterions and CampaignCriterions do.
+	Result interface{}
+	// Errors of a failed operation, their field path is relative to the
+	// upload.
+	Errors []ApiError
+}
+
+// Err returns the errors of the operation, nil if it succeeded.
+func (r BatchJobResult) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Errors))
+	for i, e := range r.Errors {
+		errs[i] = e
+	}
+	return errors.Join(errs...)
+}
+
+func (r *BatchJobResult) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	for token, err := dec.Token(); err == nil; token, err = dec.Token() {
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "index":
+			if err := dec.DecodeElement(&r.Index, &start); err != nil {
+				return err
+			}
+		case "result":
+			var operand batchJobOperand
+			if err := dec.DecodeElement(&operand, &start); err != nil {
+				return err
+			}
+			r.Result = operand.value
+		case "errorList":
+			var errorList batchJobErrorList
+			if err := dec.DecodeElement(&errorList, &start); err != nil {
+				return err
+			}
+			r.Errors = append(r.Errors, errorList...)
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// batchJobErrorList decodes the errors of a failed operation.
+type batchJobErrorList []ApiError
+
+func (l *batchJobErrorList) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	for token, err := dec.Token(); err == nil; token, err = dec.Token() {
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local != "errors" {
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+			continue
+		}
+		e, err := decodeApiError(dec, start)
+		if err != nil {
+			return err
+		}
+		*l = append(*l, e)
+	}
+	return nil
+}
+
+// batchJobOperand decodes the entity of a successful operation according
+// to its element name.
+type batchJobOperand struct {
+	value interface{}
+}
+
+func (o *batchJobOperand) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	for token, err := dec.Token(); err == nil; token, err = dec.Token() {
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		var err error
+		switch start.Name.Local {
+		case "Campaign":
+			var v Campaign
+			err = dec.DecodeElement(&v, &start)
+			o.value = v
+		case "CampaignLabel":
+			var v CampaignLabel
+			err = dec.DecodeElement(&v, &start)
+			o.value = v
+		case "CampaignCriterion":
+			var v CampaignCriterions
+			if err = dec.DecodeElement(&v, &start); err == nil && len(v) == 1 {
+				o.value = v[0]
+			}
+		case "Budget":
+			var v Budget
+			err = dec.DecodeElement(&v, &start)
+			o.value = v
+		case "AdGroup":
+			var v AdGroup
+			err = dec.DecodeElement(&v, &start)
+			o.value = v
+		case "AdGroupLabel":
+			var v AdGroupLabel
+			err = dec.DecodeElement(&v, &start)
+			o.value = v
+		case "AdGroupCriterion":
+			var v AdGroupCriterions
+			if err = dec.DecodeElement(&v, &start); err == nil && len(v) == 1 {
+				o.value = v[0]
+			}
+		case "AdGroupCriterionLabel":
+			var v AdGroupCriterionLabel
+			err = dec.DecodeElement(&v, &start)
+			o.value = v
+		case "AdGroupAd":
+			var v AdGroupAds
+			if err = dec.DecodeElement(&v, &start); err == nil && len(v) == 1 {
+				o.value = v[0]
+			}
+		case "AdGroupAdLabel":
+			var v AdGroupAdLabel
+			err = dec.DecodeElement(&v, &start)
+			o.value = v
+		case "Label":
+			var v Label
+			err = dec.DecodeElement(&v, &start)
+			o.value = v
+		default:
+			if StrictMode {
+				return fmt.Errorf("unknown batch job result -> %s", start.Name.Local)
+			}
+			err = dec.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Results downloads the results of a done batch job. When ops are the
+// uploaded operations, every result refers to its operation.
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/guides/batch-jobs#downloading_batch_job_results
+//
+func (s *BatchJobService) Results(ctx context.Context, job BatchJob, ops []BatchJobOperation) (results []BatchJobResult, err error) {
+	if job.DownloadUrl == nil || job.DownloadUrl.Url == "" {
+		return nil, fmt.Errorf("gads: batch job %d has no results to download", job.Id)
+	}
+	req, err := http.NewRequest("GET", job.DownloadUrl.Url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.Auth.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	dec := xml.NewDecoder(resp.Body)
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return results, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "rval" {
+			continue
+		}
+		var result BatchJobResult
+		if err := dec.DecodeElement(&result, &start); err != nil {
+			return results, err
+		}
+		if result.Index >= 0 && result.Index < int64(len(ops)) {
+			result.Operation = &ops[result.Index]
+		}
+		results = append(results, result)
+	}
+}
+
+// Run creates a batch job, uploads ops, waits for the job to be done and
+// returns its results.
+//
+// Example
+//
+//   ops, err := gads.NewBatchJobOperations(keywordOperations)
+//   ...
+//   job, results, err := batchJobService.Run(ctx, ops, nil)
+//   for _, r := range results {
+//     if err := r.Err(); err != nil {
+//       log.Printf("%s %v failed: %v", r.Operation.Operator, r.Operation.Operand, err)
+//     }
+//   }
+//
+func (s *BatchJobService) Run(ctx context.Context, ops []BatchJobOperation, polling *Polling) (job BatchJob, results []BatchJobResult, err error) {
+	jobs, err := s.MutateContext(ctx, BatchJobOperations{"ADD": {BatchJob{}}})
+	if err != nil {
+		return job, nil, err
+	}
+	if len(jobs) == 0 {
+		return job, nil, errors.New("gads: no batch job created")
+	}
+	job = jobs[0]
+
+	upload, err := s.NewUpload(ctx, job)
+	if err != nil {
+		return job, nil, err
+	}
+	if err = upload.Append(ctx, ops); err != nil {
+		return job, nil, err
+	}
+	if err = upload.Close(ctx); err != nil {
+		return job, nil, err
+	}
+
+	if job, err = s.Wait(ctx, job.Id, polling); err != nil {
+		return job, nil, err
+	}
+	results, err = s.Results(ctx, job, ops)
+	return job, results, err
 }
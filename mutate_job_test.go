@@ -0,0 +1,181 @@
+package gads
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testBatchJobResults = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<ns2:mutateResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809" xmlns:ns2="https://adwords.google.com/api/adwords/cm/v201809">
+  <ns2:rval>
+    <ns2:result><ns2:Campaign><ns2:id>987</ns2:id><ns2:name>batch campaign</ns2:name></ns2:Campaign></ns2:result>
+    <ns2:index>0</ns2:index>
+  </ns2:rval>
+  <ns2:rval>
+    <ns2:errorList>
+      <ns2:errors xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="ns2:EntityNotFound">
+        <ns2:fieldPath>operations[1].operand.id</ns2:fieldPath>
+        <ns2:trigger></ns2:trigger>
+        <ns2:errorString>EntityNotFound.INVALID_ID</ns2:errorString>
+        <ns2:ApiError.Type>EntityNotFound</ns2:ApiError.Type>
+        <ns2:reason>INVALID_ID</ns2:reason>
+      </ns2:errors>
+    </ns2:errorList>
+    <ns2:index>1</ns2:index>
+  </ns2:rval>
+</ns2:mutateResponse>`
+
+func testBatchJobEnvelope(action, rval string) string {
+	return `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <` + action + `Response xmlns="https://adwords.google.com/api/adwords/cm/v201809"><rval>` + rval + `</rval></` + action + `Response>
+  </soap:Body>
+</soap:Envelope>`
+}
+
+func TestBatchJobRun(t *testing.T) {
+	var mu sync.Mutex
+	var upload bytes.Buffer
+	var ranges []string
+	polls := 0
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/api/adwords/cm/v201809/BatchJobService" && r.Header.Get("Soapaction") == "mutate":
+			fmt.Fprint(w, testBatchJobEnvelope("mutate", `<value><id>42</id><status>AWAITING_FILE</status><uploadUrl><url>https://batch.example.com/upload/42</url></uploadUrl></value>`))
+		case r.URL.Path == "/api/adwords/cm/v201809/BatchJobService":
+			polls++
+			if polls == 1 {
+				fmt.Fprint(w, testBatchJobEnvelope("get", `<totalNumEntries>1</totalNumEntries><entries><id>42</id><status>ACTIVE</status></entries>`))
+				return
+			}
+			fmt.Fprint(w, testBatchJobEnvelope("get", `<totalNumEntries>1</totalNumEntries><entries><id>42</id><status>DONE</status><downloadUrl><url>https://batch.example.com/results/42</url></downloadUrl></entries>`))
+		case r.URL.Path == "/upload/42" && r.Method == "POST":
+			if r.Header.Get("x-goog-resumable") != "start" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Location", "https://batch.example.com/session/42")
+			w.WriteHeader(http.StatusCreated)
+		case r.URL.Path == "/session/42" && r.Method == "PUT":
+			b, _ := ioutil.ReadAll(r.Body)
+			upload.Write(b)
+			ranges = append(ranges, fmt.Sprintf("%s %d", r.Header.Get("Content-Range"), len(b)))
+			if strings.HasSuffix(r.Header.Get("Content-Range"), "/*") {
+				w.WriteHeader(http.StatusPermanentRedirect)
+			}
+		case r.URL.Path == "/results/42":
+			fmt.Fprint(w, testBatchJobResults)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ops, err := NewBatchJobOperations(CampaignOperations{
+		"SET": {Campaign{Id: 1, Name: "unknown campaign"}},
+		"ADD": {Campaign{Name: "batch campaign", AdServingOptimizationStatus: "OPTIMIZE"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	job, results, err := NewBatchJobService(&auth).Run(ctx, ops, &Polling{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Id != 42 || job.Status != BatchJobStatusDone || polls != 2 {
+		t.Errorf("unexpected job %+v after %d polls", job, polls)
+	}
+
+	size := upload.Len()
+	want := []string{
+		fmt.Sprintf("bytes 0-%d/* %d", batchJobChunkSize-1, batchJobChunkSize),
+		fmt.Sprintf("bytes %d-%d/%d %d", batchJobChunkSize, size-1, size, size-batchJobChunkSize),
+	}
+	if strings.Join(ranges, ",") != strings.Join(want, ",") {
+		t.Errorf("unexpected upload ranges %v, want %v", ranges, want)
+	}
+	var uploaded struct {
+		Operations []struct {
+			Type     string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+			Operator string `xml:"operator"`
+			Name     string `xml:"operand>name"`
+			Status   string `xml:"operand>adServingOptimizationStatus"`
+		} `xml:"operations"`
+	}
+	if err := xml.Unmarshal(upload.Bytes(), &uploaded); err != nil {
+		t.Fatalf("invalid upload: %v\n%s", err, upload.String())
+	}
+	if len(uploaded.Operations) != 2 ||
+		uploaded.Operations[0].Type != "CampaignOperation" ||
+		uploaded.Operations[0].Operator != "ADD" ||
+		uploaded.Operations[0].Status != "" ||
+		uploaded.Operations[1].Operator != "SET" {
+		t.Errorf("unexpected uploaded operations %+v", uploaded.Operations)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if c, ok := results[0].Result.(Campaign); !ok || c.Id != 987 || results[0].Err() != nil || results[0].Operation != &ops[0] {
+		t.Errorf("unexpected first result %+v", results[0])
+	}
+	var notFound EntityNotFound
+	if !errors.As(results[1].Err(), &notFound) || results[1].Operation.Operator != "SET" {
+		t.Errorf("unexpected second result %+v", results[1])
+	}
+	if i, _ := notFound.GetOperationIndex(); i != 1 {
+		t.Errorf("unexpected operation index %d", i)
+	}
+}
+
+func TestNewBatchJobOperations(t *testing.T) {
+	ops, err := NewBatchJobOperations(AdGroupCriterionOperations{
+		"ADD":    {BiddableAdGroupCriterion{AdGroupId: 1, Criterion: KeywordCriterion{Text: "flights", MatchType: "EXACT"}}},
+		"REMOVE": {NegativeAdGroupCriterion{AdGroupId: 1, Criterion: KeywordCriterion{Id: 2}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 2 || ops[0].Operator != "REMOVE" || ops[1].Operator != "ADD" || ops[1].Type != "AdGroupCriterionOperation" {
+		t.Errorf("unexpected operations %+v", ops)
+	}
+	b, err := xml.Marshal(ops[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `xsi:type="BiddableAdGroupCriterion"`) || !strings.Contains(string(b), `xsi:type="AdGroupCriterionOperation"`) {
+		t.Errorf("operation without xsi types %s", b)
+	}
+
+	if _, err := NewBatchJobOperations(map[string][]Campaign{}); err == nil {
+		t.Error("expected an error for an unsupported operations type")
+	}
+}
+
+func TestBatchJobWaitProcessingErrors(t *testing.T) {
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testBatchJobEnvelope("get", `<totalNumEntries>1</totalNumEntries><entries><id>42</id><status>DONE</status>`+
+			`<processingErrors><fieldPath>operations[0]</fieldPath><errorString>BatchJobProcessingError.FILE_FORMAT_ERROR</errorString><reason>FILE_FORMAT_ERROR</reason></processingErrors></entries>`))
+	})
+	job, err := NewBatchJobService(&auth).Wait(context.Background(), 42, &Polling{Interval: time.Millisecond})
+	if !errors.Is(err, ErrBatchJobProcessing) {
+		t.Fatalf("expected a processing error, got %v", err)
+	}
+	var processingErr BatchJobProcessingError
+	if !errors.As(err, &processingErr) || processingErr.Reason != "FILE_FORMAT_ERROR" || len(job.ProcessingErrors) != 1 {
+		t.Errorf("unexpected error %v for job %+v", err, job)
+	}
+}
@@ -0,0 +1,260 @@
+// Package otelgads instruments gads with OpenTelemetry.
+//
+// Every soap call and every report download gets a client span and is
+// recorded in a latency histogram, failures are counted by fault type.
+//
+//   auth, _ := gads.NewCredentials(ctx)
+//   otelgads.Instrument(&auth.Auth)
+//   cs := gads.NewCampaignService(&auth.Auth)
+//
+// The global tracer and meter providers are used unless other ones are
+// given with WithTracerProvider and WithMeterProvider.
+package otelgads
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/querian/gads"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/querian/gads/otelgads"
+
+// Attribute keys set on the spans and the metrics.
+const (
+	ServiceKey    = attribute.Key("gads.service")
+	MethodKey     = attribute.Key("gads.method")
+	CustomerIdKey = attribute.Key("gads.customer_id")
+	RequestIdKey  = attribute.Key("gads.request_id")
+	OperationsKey = attribute.Key("gads.operations")
+	ReportTypeKey = attribute.Key("gads.report_type")
+	FormatKey     = attribute.Key("gads.format")
+	FaultTypeKey  = attribute.Key("gads.fault_type")
+)
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the provider of the tracer creating the spans.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = tp }
+}
+
+// WithMeterProvider sets the provider of the meter recording the metrics.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.meterProvider = mp }
+}
+
+// instruments holds the tracer and the metric instruments shared by the
+// soap and the report middlewares.
+type instruments struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+func newInstruments(opts []Option) *instruments {
+	c := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	meter := c.meterProvider.Meter(instrumentationName)
+	// instrument creation only fails on invalid names, which are constant
+	duration, _ := meter.Float64Histogram(
+		"gads.call.duration",
+		metric.WithDescription("Duration of the adwords api calls."),
+		metric.WithUnit("s"),
+	)
+	errs, _ := meter.Int64Counter(
+		"gads.call.errors",
+		metric.WithDescription("Number of failed adwords api calls by fault type."),
+		metric.WithUnit("{error}"),
+	)
+	return &instruments{
+		tracer:   c.tracerProvider.Tracer(instrumentationName),
+		duration: duration,
+		errors:   errs,
+	}
+}
+
+// Instrument appends the soap and the report middlewares to auth.
+func Instrument(auth *gads.Auth, opts ...Option) {
+	in := newInstruments(opts)
+	auth.Middlewares = append(auth.Middlewares, in.middleware)
+	auth.ReportMiddlewares = append(auth.ReportMiddlewares, in.reportMiddleware)
+}
+
+// Middleware returns a gads.Middleware tracing and measuring soap calls.
+func Middleware(opts ...Option) gads.Middleware {
+	return newInstruments(opts).middleware
+}
+
+// ReportMiddleware returns a gads.ReportMiddleware tracing and measuring
+// report downloads. The span of a successful download ends when its body
+// is closed.
+func ReportMiddleware(opts ...Option) gads.ReportMiddleware {
+	return newInstruments(opts).reportMiddleware
+}
+
+func (in *instruments) middleware(next gads.SoapHandler) gads.SoapHandler {
+	return func(ctx context.Context, call *gads.SoapCall) error {
+		attrs := []attribute.KeyValue{
+			ServiceKey.String(call.Service.Name),
+			MethodKey.String(call.Action),
+		}
+		ctx, span := in.tracer.Start(ctx, call.Service.Name+"/"+call.Action,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...),
+			trace.WithAttributes(CustomerIdKey.String(call.CustomerId)),
+		)
+		defer span.End()
+
+		start := time.Now()
+		err := next(ctx, call)
+
+		span.SetAttributes(
+			RequestIdKey.String(call.ResponseHeader.RequestId),
+			OperationsKey.Int64(call.ResponseHeader.Operations),
+		)
+		in.record(ctx, span, start, attrs, err)
+		return err
+	}
+}
+
+func (in *instruments) reportMiddleware(next gads.ReportHandler) gads.ReportHandler {
+	return func(ctx context.Context, call *gads.ReportCall) (io.ReadCloser, error) {
+		name, method := "ReportDefinitionService/download", "download"
+		if call.ReportType == "" {
+			name, method = "AWQL/download", "awql"
+		}
+		attrs := []attribute.KeyValue{
+			ServiceKey.String("ReportDefinitionService"),
+			MethodKey.String(method),
+		}
+		ctx, span := in.tracer.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...),
+			trace.WithAttributes(
+				CustomerIdKey.String(call.CustomerId),
+				ReportTypeKey.String(call.ReportType),
+				FormatKey.String(call.Format),
+			),
+		)
+
+		start := time.Now()
+		body, err := next(ctx, call)
+		if err != nil {
+			in.record(ctx, span, start, attrs, err)
+			span.End()
+			return nil, err
+		}
+		return &tracedBody{ReadCloser: body, end: func(err error) {
+			in.record(ctx, span, start, attrs, err)
+			span.End()
+		}}, nil
+	}
+}
+
+// record sets the span status and records the call in the metrics.
+func (in *instruments) record(ctx context.Context, span trace.Span, start time.Time, attrs []attribute.KeyValue, err error) {
+	if err != nil {
+		faultType := FaultType(err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, faultType)
+		span.SetAttributes(FaultTypeKey.String(faultType))
+		in.errors.Add(ctx, 1, metric.WithAttributes(append(attrs, FaultTypeKey.String(faultType))...))
+	}
+	in.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+}
+
+// tracedBody ends the span of a report download once it has been read
+// and closed.
+type tracedBody struct {
+	io.ReadCloser
+	readErr error
+	end     func(err error)
+}
+
+func (b *tracedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		b.readErr = err
+	}
+	return n, err
+}
+
+func (b *tracedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if b.end != nil {
+		readErr := b.readErr
+		if readErr == nil {
+			readErr = err
+		}
+		b.end(readErr)
+		b.end = nil
+	}
+	return err
+}
+
+// FaultType returns the name used to count err: the type of the first api
+// error of a soap fault, the api error type of a refused report download,
+// "HTTPStatusError" for 5xx responses without a soap body and the go type
+// of any other error.
+func FaultType(err error) string {
+	var faults *gads.ErrorsType
+	if errors.As(err, &faults) {
+		if apiErrors := faults.ApiErrors(); len(apiErrors) > 0 {
+			if unknown, ok := apiErrors[0].(gads.UnknownError); ok && unknown.ApiErrorType != "" {
+				return unknown.ApiErrorType
+			}
+			return typeName(apiErrors[0])
+		}
+		return "ApiException"
+	}
+
+	var reportErr *gads.ReportError
+	if errors.As(err, &reportErr) {
+		if kind, _, _ := strings.Cut(reportErr.Type, "."); kind != "" {
+			return kind
+		}
+		return "ReportError"
+	}
+
+	var statusErr *gads.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return "HTTPStatusError"
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "ContextError"
+	}
+	return typeName(err)
+}
+
+func typeName(v interface{}) string {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Name() == "" {
+		return t.String()
+	}
+	return t.Name()
+}
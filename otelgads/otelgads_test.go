@@ -0,0 +1,229 @@
+package otelgads
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/querian/gads"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const testGetResponse = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Header>
+    <ResponseHeader xmlns="https://adwords.google.com/api/adwords/cm/v201809">
+      <requestId>0005a1b2c3d5</requestId>
+      <serviceName>CampaignService</serviceName>
+      <methodName>get</methodName>
+      <operations>1</operations>
+      <responseTime>42</responseTime>
+    </ResponseHeader>
+  </soap:Header>
+  <soap:Body>
+    <getResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809">
+      <rval>
+        <totalNumEntries>0</totalNumEntries>
+      </rval>
+    </getResponse>
+  </soap:Body>
+</soap:Envelope>`
+
+const testEntityNotFoundFault = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <soap:Fault>
+      <faultcode>soap:Server</faultcode>
+      <faultstring>[EntityNotFound.INVALID_ID @ operations[0].operand.id]</faultstring>
+      <detail>
+        <ApiExceptionFault xmlns="https://adwords.google.com/api/adwords/cm/v201809">
+          <message>[EntityNotFound.INVALID_ID @ operations[0].operand.id]</message>
+          <ApplicationException.Type>ApiException</ApplicationException.Type>
+          <errors xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="EntityNotFound">
+            <fieldPath>operations[0].operand.id</fieldPath>
+            <trigger></trigger>
+            <errorString>EntityNotFound.INVALID_ID</errorString>
+            <ApiError.Type>EntityNotFound</ApiError.Type>
+            <reason>INVALID_ID</reason>
+          </errors>
+        </ApiExceptionFault>
+      </detail>
+    </soap:Fault>
+  </soap:Body>
+</soap:Envelope>`
+
+// testAuth returns an instrumented Auth whose requests are all sent to
+// handler, along with the span recorder and the metric reader.
+func testAuth(t *testing.T, handler http.HandlerFunc) (gads.Auth, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := server.Client()
+	client.Transport = rewriteTransport{base: client.Transport, url: server.URL}
+	client.Timeout = 10 * time.Minute
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	auth := gads.Auth{
+		CustomerId:     "123-456-7890",
+		DeveloperToken: "dev-token",
+		UserAgent:      "gads-test",
+		Client:         client,
+	}
+	Instrument(&auth, WithTracerProvider(tp), WithMeterProvider(mp))
+	return auth, exporter, reader
+}
+
+// rewriteTransport sends every request to the test server.
+type rewriteTransport struct {
+	base http.RoundTripper
+	url  string
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Scheme = "http"
+	r.URL.Host = t.url[len("http://"):]
+	return t.base.RoundTrip(r)
+}
+
+func spanAttr(attrs []attribute.KeyValue, key attribute.Key) attribute.Value {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	metrics := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+func TestSoapSpans(t *testing.T) {
+	calls := 0
+	auth, exporter, reader := testAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls > 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, testEntityNotFoundFault)
+			return
+		}
+		fmt.Fprint(w, testGetResponse)
+	})
+	cs := gads.NewCampaignService(&auth)
+
+	if _, _, err := cs.Get(gads.Selector{Fields: []string{"Id"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cs.Get(gads.Selector{Fields: []string{"Id"}}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	ok, failed := spans[0], spans[1]
+	if ok.Name != "CampaignService/get" {
+		t.Errorf("unexpected span name %q", ok.Name)
+	}
+	for key, want := range map[attribute.Key]string{
+		ServiceKey:    "CampaignService",
+		MethodKey:     "get",
+		CustomerIdKey: "123-456-7890",
+		RequestIdKey:  "0005a1b2c3d5",
+	} {
+		if got := spanAttr(ok.Attributes, key).AsString(); got != want {
+			t.Errorf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if got := spanAttr(ok.Attributes, OperationsKey).AsInt64(); got != 1 {
+		t.Errorf("expected 1 operation, got %d", got)
+	}
+	if failed.Status.Code != codes.Error {
+		t.Errorf("expected the failed span to have an error status, got %v", failed.Status)
+	}
+	if got := spanAttr(failed.Attributes, FaultTypeKey).AsString(); got != "EntityNotFound" {
+		t.Errorf("expected the EntityNotFound fault type, got %q", got)
+	}
+
+	metrics := collect(t, reader)
+	duration, ok2 := metrics["gads.call.duration"].(metricdata.Histogram[float64])
+	if !ok2 || len(duration.DataPoints) != 1 || duration.DataPoints[0].Count != 2 {
+		t.Errorf("expected 2 recorded durations, got %#v", metrics["gads.call.duration"])
+	}
+	errs, ok2 := metrics["gads.call.errors"].(metricdata.Sum[int64])
+	if !ok2 || len(errs.DataPoints) != 1 || errs.DataPoints[0].Value != 1 {
+		t.Fatalf("expected 1 counted error, got %#v", metrics["gads.call.errors"])
+	}
+	if v, _ := errs.DataPoints[0].Attributes.Value(FaultTypeKey); v.AsString() != "EntityNotFound" {
+		t.Errorf("expected the error to be counted as EntityNotFound, got %q", v.AsString())
+	}
+}
+
+func TestReportSpans(t *testing.T) {
+	auth, exporter, _ := testAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "Day,Clicks\n2019-01-01,12\n")
+	})
+
+	body, err := gads.NewAWQLClient(&auth).Download(gads.AWQLRequest{
+		Query:  "SELECT Date, Clicks FROM ACCOUNT_PERFORMANCE_REPORT",
+		Format: gads.DownloadFormatCSV,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(exporter.GetSpans()); n != 0 {
+		t.Fatalf("span should end with the body, got %d ended spans", n)
+	}
+	if _, err := io.Copy(ioutil.Discard, body); err != nil {
+		t.Fatal(err)
+	}
+	body.Close()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name != "AWQL/download" {
+		t.Errorf("unexpected span name %q", spans[0].Name)
+	}
+	if got := spanAttr(spans[0].Attributes, FormatKey).AsString(); got != "CSV" {
+		t.Errorf("expected the CSV format, got %q", got)
+	}
+}
+
+func TestFaultType(t *testing.T) {
+	for err, want := range map[error]string{
+		&gads.HTTPStatusError{StatusCode: 502}: "HTTPStatusError",
+		&gads.ReportError{StatusCode: 400, Type: "ReportDefinitionError.INVALID_FIELD_NAME_FOR_REPORT"}: "ReportDefinitionError",
+		&gads.ReportError{StatusCode: 502}: "ReportError",
+		context.DeadlineExceeded:           "ContextError",
+		io.ErrUnexpectedEOF:                "errorString",
+	} {
+		if got := FaultType(err); got != want {
+			t.Errorf("FaultType(%v) = %q, want %q", err, got, want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package gads
+
+import (
+	"context"
+	"iter"
+)
+
+const defaultPageSize = 500
+
+// Pager lazily walks every entry matching a selector, page after page,
+// over the GetContext method of any service returning a total count.
+//
+// Example
+//
+//   pager := gads.NewPager(ctx, campaignService.GetContext, gads.Selector{
+//     Fields: []string{"Id", "Name", "Status"},
+//   })
+//   pager.Prefetch = true
+//   for pager.Next() {
+//     campaign := pager.Entry()
+//     fmt.Println(campaign.Id, campaign.Name)
+//   }
+//   if err := pager.Err(); err != nil {
+//     log.Fatal(err)
+//   }
+//
+// Services whose get has another signature can be adapted with a closure.
+//
+//   get := func(ctx context.Context, sel gads.Selector) ([]gads.ManagedCustomer, int64, error) {
+//     customers, _, totalCount, err := managedCustomerService.GetContext(ctx, sel)
+//     return customers, totalCount, err
+//   }
+//   pager := gads.NewPager(ctx, get, selector)
+//
+// A Pager is not safe for concurrent use.
+type Pager[T any] struct {
+	// PageSize is the number of entries requested at once. It defaults to
+	// the limit of the selector paging, or to 500.
+	PageSize int64
+	// Prefetch requests the next page in the background while the
+	// current one is consumed.
+	Prefetch bool
+
+	ctx      context.Context
+	get      func(ctx context.Context, selector Selector) ([]T, int64, error)
+	selector Selector
+
+	offset     int64
+	totalCount int64
+	page       []T
+	i          int
+	entry      T
+	pending    <-chan pageResult[T]
+	done       bool
+	err        error
+}
+
+type pageResult[T any] struct {
+	entries    []T
+	totalCount int64
+	err        error
+}
+
+// NewPager creates a Pager over get starting at the offset of the
+// selector paging, if any.
+func NewPager[S ~[]T, T any](
+	ctx context.Context,
+	get func(ctx context.Context, selector Selector) (S, int64, error),
+	selector Selector,
+) *Pager[T] {
+	p := &Pager[T]{
+		ctx: ctx,
+		get: func(ctx context.Context, selector Selector) ([]T, int64, error) {
+			entries, totalCount, err := get(ctx, selector)
+			return []T(entries), totalCount, err
+		},
+		selector: selector,
+	}
+	if selector.Paging != nil {
+		p.offset = selector.Paging.Offset
+		p.PageSize = selector.Paging.Limit
+	}
+	return p
+}
+
+func (p *Pager[T]) pageSize() int64 {
+	if p.PageSize <= 0 {
+		return defaultPageSize
+	}
+	return p.PageSize
+}
+
+// fetch requests the page starting at offset.
+func (p *Pager[T]) fetch(offset int64) pageResult[T] {
+	selector := p.selector
+	selector.Paging = &Paging{Offset: offset, Limit: p.pageSize()}
+	entries, totalCount, err := p.get(p.ctx, selector)
+	return pageResult[T]{entries, totalCount, err}
+}
+
+// prefetch requests the page starting at offset in the background.
+func (p *Pager[T]) prefetch(offset int64) <-chan pageResult[T] {
+	c := make(chan pageResult[T], 1)
+	go func() {
+		c <- p.fetch(offset)
+	}()
+	return c
+}
+
+// nextPage loads the page at the current offset.
+func (p *Pager[T]) nextPage() {
+	var r pageResult[T]
+	if p.pending != nil {
+		r = <-p.pending
+		p.pending = nil
+	} else {
+		r = p.fetch(p.offset)
+	}
+	if r.err != nil {
+		p.err = r.err
+		return
+	}
+
+	p.page, p.i, p.totalCount = r.entries, 0, r.totalCount
+	p.offset += p.pageSize()
+	// an empty page ends the walk even if totalNumEntries is off
+	if p.offset >= p.totalCount || len(r.entries) == 0 {
+		p.done = true
+		return
+	}
+	if p.Prefetch {
+		p.pending = p.prefetch(p.offset)
+	}
+}
+
+// Next advances to the next entry, requesting the next page when the
+// current one is exhausted. It returns false at the end of the entries
+// or on error.
+func (p *Pager[T]) Next() bool {
+	for p.i >= len(p.page) {
+		if p.done || p.err != nil {
+			return false
+		}
+		p.nextPage()
+	}
+	p.entry = p.page[p.i]
+	p.i++
+	return true
+}
+
+// Entry returns the current entry.
+func (p *Pager[T]) Entry() T {
+	return p.entry
+}
+
+// TotalCount returns the totalNumEntries of the last page received.
+func (p *Pager[T]) TotalCount() int64 {
+	return p.totalCount
+}
+
+// Err returns the error that stopped the pager, if any.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All returns an iterator over the remaining entries, an error is yielded
+// last with the zero entry.
+//
+//   for campaign, err := range gads.NewPager(ctx, cs.GetContext, selector).All() {
+//     if err != nil {
+//       return err
+//     }
+//     fmt.Println(campaign.Id)
+//   }
+func (p *Pager[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p.Next() {
+			if !yield(p.entry, nil) {
+				return
+			}
+		}
+		if p.err != nil {
+			var zero T
+			yield(zero, p.err)
+		}
+	}
+}
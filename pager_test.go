@@ -0,0 +1,124 @@
+package gads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// testGet serves totalCount integers, recording the requested offsets.
+type testGet struct {
+	mu         sync.Mutex
+	totalCount int64
+	failAt     int64
+	offsets    []int64
+}
+
+func (g *testGet) get(ctx context.Context, selector Selector) ([]int64, int64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	offset, limit := selector.Paging.Offset, selector.Paging.Limit
+	g.offsets = append(g.offsets, offset)
+	if g.failAt > 0 && offset >= g.failAt {
+		return nil, 0, errors.New("boom")
+	}
+	var entries []int64
+	for i := offset; i < offset+limit && i < g.totalCount; i++ {
+		entries = append(entries, i)
+	}
+	return entries, g.totalCount, nil
+}
+
+func TestPager(t *testing.T) {
+	for _, tc := range []struct {
+		totalCount int64
+		pageSize   int64
+		prefetch   bool
+		offsets    string
+	}{
+		{0, 10, false, "[0]"},
+		{25, 10, false, "[0 10 20]"},
+		// no extra request for the empty page after the last one
+		{20, 10, false, "[0 10]"},
+		{20, 10, true, "[0 10]"},
+		{25, 10, true, "[0 10 20]"},
+	} {
+		g := &testGet{totalCount: tc.totalCount}
+		p := NewPager(context.Background(), g.get, Selector{})
+		p.PageSize, p.Prefetch = tc.pageSize, tc.prefetch
+
+		var n int64
+		for p.Next() {
+			if p.Entry() != n {
+				t.Fatalf("expected entry %d, got %d", n, p.Entry())
+			}
+			n++
+		}
+		if err := p.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if n != tc.totalCount {
+			t.Errorf("expected %d entries, got %d", tc.totalCount, n)
+		}
+		if offsets := fmt.Sprint(g.offsets); offsets != tc.offsets {
+			t.Errorf("%d entries by %d: expected offsets %s, got %s", tc.totalCount, tc.pageSize, tc.offsets, offsets)
+		}
+	}
+}
+
+func TestPagerSelectorPaging(t *testing.T) {
+	g := &testGet{totalCount: 12}
+	p := NewPager(context.Background(), g.get, Selector{Paging: &Paging{Offset: 4, Limit: 5}})
+	var entries []int64
+	for entry, err := range p.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+	if fmt.Sprint(entries) != "[4 5 6 7 8 9 10 11]" || fmt.Sprint(g.offsets) != "[4 9]" {
+		t.Errorf("unexpected entries %v or offsets %v", entries, g.offsets)
+	}
+}
+
+func TestPagerError(t *testing.T) {
+	g := &testGet{totalCount: 30, failAt: 10}
+	p := NewPager(context.Background(), g.get, Selector{})
+	p.PageSize = 10
+
+	n := 0
+	var last error
+	for _, err := range p.All() {
+		if err != nil {
+			last = err
+			break
+		}
+		n++
+	}
+	if n != 10 || last == nil || last.Error() != "boom" {
+		t.Errorf("expected 10 entries then the error, got %d and %v", n, last)
+	}
+	if p.Next() {
+		t.Error("pager should stay stopped after an error")
+	}
+}
+
+func TestPagerService(t *testing.T) {
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testAdGroupCriterionGetResponse)
+	})
+	p := NewPager(context.Background(), NewAdGroupCriterionService(&auth).GetContext, Selector{})
+	n := 0
+	for p.Next() {
+		if _, ok := p.Entry().(BiddableAdGroupCriterion); !ok && n != 1 {
+			t.Errorf("unexpected entry %#v", p.Entry())
+		}
+		n++
+	}
+	if p.Err() != nil || n != 3 || p.TotalCount() != 3 {
+		t.Errorf("expected 3 entries, got %d, %d in total and %v", n, p.TotalCount(), p.Err())
+	}
+}
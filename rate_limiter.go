@@ -0,0 +1,263 @@
+package gads
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RateScopeAccount is the scope of the limits applied per client customer id
+	RateScopeAccount = "ACCOUNT"
+	// RateScopeDeveloper is the scope of the limits applied per developer token
+	RateScopeDeveloper = "DEVELOPER"
+
+	// a throttled bucket never goes under this fraction of its nominal rate
+	rateLimiterMinFactor = 1.0 / 16
+	// fraction of the nominal rate given back after every successful request
+	rateLimiterRecoveryFactor = 1.0 / 20
+)
+
+// RateLimiter is a client side token bucket limiter consulted before every
+// api call. It keeps one bucket per client customer id and one per developer
+// token, matching the ACCOUNT and DEVELOPER scopes of RateExceededError.
+//
+// Tokens are operations, like the OperationsByMinute quota: a mutate takes
+// as many tokens as it has operations, any other call takes one.
+//
+// When the api answers with a RateExceededError the bucket of the matching
+// scope halves its rate and stops handing out tokens for retryAfterSeconds,
+// then slowly recovers on successful calls.
+//
+// A RateLimiter is safe for concurrent use. Set it on an Auth and every
+// service created from copies of that Auth shares the same buckets.
+type RateLimiter struct {
+	accountRate   float64
+	developerRate float64
+
+	mu         sync.Mutex
+	accounts   map[string]*tokenBucket
+	developers map[string]*tokenBucket
+}
+
+// NewRateLimiter creates a RateLimiter allowing accountRate operations per
+// second for every client customer id and developerRate operations per
+// second for every developer token. A rate of 0 disables the limit of that
+// scope.
+func NewRateLimiter(accountRate, developerRate float64) *RateLimiter {
+	return &RateLimiter{
+		accountRate:   accountRate,
+		developerRate: developerRate,
+		accounts:      map[string]*tokenBucket{},
+		developers:    map[string]*tokenBucket{},
+	}
+}
+
+// buckets returns the buckets applying to a, developer scope first.
+func (l *RateLimiter) buckets(a *Auth) (buckets []*tokenBucket) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.developerRate > 0 {
+		buckets = append(buckets, l.bucket(l.developers, a.DeveloperToken, l.developerRate))
+	}
+	if l.accountRate > 0 {
+		buckets = append(buckets, l.bucket(l.accounts, a.CustomerId, l.accountRate))
+	}
+	return buckets
+}
+
+func (l *RateLimiter) bucket(m map[string]*tokenBucket, key string, rate float64) *tokenBucket {
+	b, ok := m[key]
+	if !ok {
+		b = newTokenBucket(rate)
+		m[key] = b
+	}
+	return b
+}
+
+// Wait blocks until a request of a single operation for a can be sent or
+// ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, a *Auth) error {
+	return l.WaitN(ctx, a, 1)
+}
+
+// WaitN blocks until a request of n operations for a can be sent or ctx is
+// done. A request larger than the rate waits until the bucket refilled the
+// missing tokens. When ctx is done, the tokens taken are given back.
+func (l *RateLimiter) WaitN(ctx context.Context, a *Auth, n int) error {
+	if l == nil {
+		return nil
+	}
+	if n < 1 {
+		n = 1
+	}
+	buckets := l.buckets(a)
+	for i, b := range buckets {
+		if err := b.wait(ctx, float64(n)); err != nil {
+			for _, taken := range buckets[:i] {
+				taken.giveBack(float64(n))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// callOperations returns the number of operations of a soap body, the
+// length of the operations of a mutate or 1 for any other call.
+func callOperations(body interface{}) int {
+	v := reflect.Indirect(reflect.ValueOf(body))
+	if v.Kind() != reflect.Struct {
+		return 1
+	}
+	for i := 0; i < v.NumField(); i++ {
+		name := strings.Split(v.Type().Field(i).Tag.Get("xml"), ",")[0]
+		if j := strings.LastIndexByte(name, ' '); j >= 0 {
+			name = name[j+1:]
+		}
+		if name == "operations" && v.Field(i).Kind() == reflect.Slice && v.Field(i).Len() > 0 {
+			return v.Field(i).Len()
+		}
+	}
+	return 1
+}
+
+// observe adapts the rate of the buckets of a to the result of a request.
+func (l *RateLimiter) observe(a *Auth, err error) {
+	if l == nil {
+		return
+	}
+
+	if err == nil {
+		for _, b := range l.buckets(a) {
+			b.relax()
+		}
+		return
+	}
+	var faults *ErrorsType
+	if !errors.As(err, &faults) {
+		return
+	}
+
+	l.mu.Lock()
+	developer := l.developers[a.DeveloperToken]
+	account := l.accounts[a.CustomerId]
+	l.mu.Unlock()
+	for _, e := range faults.ApiErrors() {
+		re, ok := e.(RateExceededError)
+		if !ok {
+			continue
+		}
+		retryAfter := time.Duration(re.RetryAfterSeconds) * time.Second
+		switch re.RateScope {
+		case RateScopeDeveloper:
+			developer.throttle(retryAfter)
+		default:
+			account.throttle(retryAfter)
+		}
+	}
+}
+
+// tokenBucket is a token bucket whose rate can be lowered when the api
+// complains and raised back afterwards.
+type tokenBucket struct {
+	mu           sync.Mutex
+	nominal      float64 // configured tokens per second
+	rate         float64 // current tokens per second
+	burst        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+	now          func() time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	burst := math.Max(1, math.Ceil(rate))
+	return &tokenBucket{
+		nominal: rate,
+		rate:    rate,
+		burst:   burst,
+		tokens:  burst,
+		now:     time.Now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+}
+
+// reserve takes n tokens and returns how long to wait before using them.
+func (b *tokenBucket) reserve(n float64) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	b.refill(now)
+	b.tokens -= n
+	var d time.Duration
+	if b.tokens < 0 {
+		d = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	if blocked := b.blockedUntil.Sub(now); blocked > d {
+		d = blocked
+	}
+	return d
+}
+
+func (b *tokenBucket) wait(ctx context.Context, n float64) error {
+	d := b.reserve(n)
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		b.giveBack(n)
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// giveBack returns n tokens taken but not used.
+func (b *tokenBucket) giveBack(n float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(b.now())
+	b.tokens = math.Min(b.burst, b.tokens+n)
+}
+
+// throttle halves the rate and holds every token for retryAfter.
+func (b *tokenBucket) throttle(retryAfter time.Duration) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	b.refill(now)
+	b.rate = math.Max(b.rate/2, b.nominal*rateLimiterMinFactor)
+	if until := now.Add(retryAfter); until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+	if b.tokens > 0 {
+		b.tokens = 0
+	}
+}
+
+// relax raises the rate back towards the nominal one.
+func (b *tokenBucket) relax() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.rate < b.nominal {
+		b.refill(b.now())
+		b.rate = math.Min(b.nominal, b.rate+b.nominal*rateLimiterRecoveryFactor)
+	}
+}
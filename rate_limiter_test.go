@@ -0,0 +1,144 @@
+package gads
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTokenBucket(2)
+	b.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if d := b.reserve(1); d != 0 {
+			t.Fatalf("burst token %d should not wait, got %v", i, d)
+		}
+	}
+	if d := b.reserve(1); d != 500*time.Millisecond {
+		t.Errorf("expected to wait 500ms, got %v", d)
+	}
+
+	now = now.Add(10 * time.Second)
+	b.throttle(30 * time.Second)
+	if b.rate != 1 {
+		t.Errorf("expected the rate to be halved, got %v", b.rate)
+	}
+	if d := b.reserve(1); d != 30*time.Second {
+		t.Errorf("expected to wait for retryAfter, got %v", d)
+	}
+
+	for i := 0; i < 40; i++ {
+		b.relax()
+	}
+	if b.rate != b.nominal {
+		t.Errorf("expected the rate to recover to %v, got %v", b.nominal, b.rate)
+	}
+}
+
+func TestRateLimiterScopes(t *testing.T) {
+	l := NewRateLimiter(1, 10)
+	a1 := Auth{CustomerId: "1", DeveloperToken: "token"}
+	a2 := a1
+	a2.CustomerId = "2"
+
+	if len(l.buckets(&a1)) != 2 {
+		t.Fatal("expected a developer and an account bucket")
+	}
+	if l.buckets(&a1)[0] != l.buckets(&a2)[0] {
+		t.Error("developer bucket should be shared between accounts")
+	}
+	if l.buckets(&a1)[1] == l.buckets(&a2)[1] {
+		t.Error("account buckets should not be shared between accounts")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, &a1); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Wait(ctx, &a1); err == nil {
+		t.Error("second request of the second should wait past the deadline")
+	}
+	if err := l.Wait(ctx, &a2); err != nil {
+		t.Errorf("other account should not be throttled: %v", err)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewRateLimiter(1, 10)
+	a := Auth{CustomerId: "1", DeveloperToken: "token"}
+	buckets := l.buckets(&a)
+	for _, b := range buckets {
+		b.now = func() time.Time { return now }
+	}
+	developer, account := buckets[0], buckets[1]
+
+	if err := l.Wait(context.Background(), &a); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.WaitN(ctx, &a, 2); err == nil {
+		t.Fatal("expected the account bucket to wait past the deadline")
+	}
+	if developer.tokens != 9 || account.tokens != 0 {
+		t.Errorf("expected the tokens of the cancelled call to be given back, got %v developer and %v account tokens",
+			developer.tokens, account.tokens)
+	}
+}
+
+func TestRateLimiterAdapts(t *testing.T) {
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, testRateExceededFault)
+	})
+	auth.Limiter = NewRateLimiter(8, 0)
+
+	// the limiter is shared by copies of auth
+	_, _, err := NewCampaignService(&auth).Get(Selector{Fields: []string{"Id"}})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if rate := auth.Limiter.accounts[auth.CustomerId].rate; rate != 4 {
+		t.Errorf("expected the account rate to be halved, got %v", rate)
+	}
+}
+
+func TestRateLimiterOperations(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTokenBucket(10)
+	b.now = func() time.Time { return now }
+	if d := b.reserve(5); d != 0 {
+		t.Fatalf("5 operations within the burst should not wait, got %v", d)
+	}
+	if d := b.reserve(25); d != 2*time.Second {
+		t.Errorf("expected 25 operations to wait for 20 tokens, got %v", d)
+	}
+
+	type operation struct {
+		Action string `xml:"operator"`
+	}
+	mutation := struct {
+		XMLName xml.Name
+		Ops     []operation `xml:"operations"`
+	}{XMLName: xml.Name{Space: baseUrl, Local: "mutate"}, Ops: make([]operation, 3)}
+	if n := callOperations(mutation); n != 3 {
+		t.Errorf("expected a mutate of 3 operations, got %d", n)
+	}
+	if n := callOperations(struct{ Sel Selector }{}); n != 1 {
+		t.Errorf("expected a get to cost 1 operation, got %d", n)
+	}
+	call, err := (&Auth{}).newSoapCall(campaignServiceUrl, "mutate", &mutation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Operations != 3 {
+		t.Errorf("unexpected call operations %d", call.Operations)
+	}
+}
@@ -0,0 +1,126 @@
+package gads
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// reportHeaders are the download options of the reporting api, shared by
+// ReportDefinition and AWQLRequest.
+type reportHeaders struct {
+	SkipReportHeader       bool
+	SkipColumnHeader       bool
+	SkipReportSummary      bool
+	IncludeZeroImpressions bool
+	UseRawEnumValues       bool
+}
+
+// newReportRequest creates the http request of a report download for
+// customerId, form holding either the report definition or the query.
+func (a *Auth) newReportRequest(customerId string, form url.Values, h reportHeaders) (*http.Request, error) {
+	req, err := http.NewRequest("POST", reportAPIURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "*/*")
+	req.Header.Add("developerToken", a.DeveloperToken)
+	req.Header.Add("clientCustomerId", customerId)
+	req.Header.Add("skipReportHeader", strconv.FormatBool(h.SkipReportHeader))
+	req.Header.Add("skipColumnHeader", strconv.FormatBool(h.SkipColumnHeader))
+	req.Header.Add("skipReportSummary", strconv.FormatBool(h.SkipReportSummary))
+	req.Header.Add("includeZeroImpressions", strconv.FormatBool(h.IncludeZeroImpressions))
+	req.Header.Add("useRawEnumValues", strconv.FormatBool(h.UseRawEnumValues))
+	return req, nil
+}
+
+// sendReport sends req with client through the report middlewares and
+// returns the body of the report, or a *ReportError.
+func (a *Auth) sendReport(ctx context.Context, call *ReportCall, req *http.Request, client *http.Client) (io.ReadCloser, error) {
+	return a.downloadReport(ctx, call, func(ctx context.Context, call *ReportCall) (io.ReadCloser, error) {
+		resp, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		call.StatusCode = resp.StatusCode
+		if resp.StatusCode != http.StatusOK {
+			defer resp.Body.Close()
+			return nil, newReportError(resp)
+		}
+		return resp.Body, nil
+	})
+}
+
+// ReportError is returned when the reporting api refuses to download a
+// report.
+type ReportError struct {
+	StatusCode int
+	// Type is the api error type and reason, like
+	// "ReportDefinitionError.INVALID_FIELD_NAME_FOR_REPORT".
+	Type      string
+	Trigger   string
+	FieldPath string
+	// Body is the start of the response body when it wasn't a report
+	// download error.
+	Body string
+}
+
+func (e *ReportError) Error() string {
+	if e.Type == "" {
+		if e.Body == "" {
+			return fmt.Sprintf("gads: report download failed with status %d", e.StatusCode)
+		}
+		return fmt.Sprintf("gads: report download failed with status %d: %s", e.StatusCode, e.Body)
+	}
+	msg := fmt.Sprintf("gads: report download failed with status %d: %s", e.StatusCode, e.Type)
+	if e.FieldPath != "" {
+		msg += " @ " + e.FieldPath
+	}
+	if e.Trigger != "" {
+		msg += " ; trigger:'" + e.Trigger + "'"
+	}
+	return msg
+}
+
+// Temporary reports whether downloading the report again may succeed,
+// for rate limits, internal api errors and 5xx statuses.
+func (e *ReportError) Temporary() bool {
+	return e.StatusCode >= 500 ||
+		strings.HasPrefix(e.Type, "RateExceededError") ||
+		strings.HasPrefix(e.Type, "InternalApiError")
+}
+
+// newReportError reads the error of a failed report download.
+func newReportError(resp *http.Response) error {
+	e := &ReportError{StatusCode: resp.StatusCode}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return e
+	}
+
+	var downloadErr struct {
+		XMLName   xml.Name `xml:"reportDownloadError"`
+		Type      string   `xml:"ApiError>type"`
+		Trigger   string   `xml:"ApiError>trigger"`
+		FieldPath string   `xml:"ApiError>fieldPath"`
+	}
+	if xml.Unmarshal(body, &downloadErr) == nil && downloadErr.Type != "" {
+		e.Type = downloadErr.Type
+		e.Trigger = downloadErr.Trigger
+		e.FieldPath = downloadErr.FieldPath
+		return e
+	}
+
+	if len(body) > maxSizeForNotValidBody {
+		body = body[:maxSizeForNotValidBody]
+	}
+	e.Body = strings.TrimSpace(string(body))
+	return e
+}
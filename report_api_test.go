@@ -0,0 +1,108 @@
+package gads
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportErrors(t *testing.T) {
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.FormValue("__rdquery"), "Broken") {
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, "<html><body>The server encountered a temporary error</body></html>")
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<reportDownloadError><ApiError><type>ReportDefinitionError.INVALID_FIELD_NAME_FOR_REPORT</type><trigger>Clickz</trigger><fieldPath>selector.fields</fieldPath></ApiError></reportDownloadError>`)
+	})
+	auth.Client.Timeout = 10 * time.Minute
+
+	def := testAccountReportDefinition
+	_, defErr := NewReportDefinitionService(&auth).Request(&def)
+	_, awqlErr := NewAWQLClient(&auth).Download(AWQLRequest{
+		Query:  "SELECT Clickz FROM CAMPAIGN_PERFORMANCE_REPORT",
+		Format: DownloadFormatCSV,
+	})
+	for _, err := range []error{defErr, awqlErr} {
+		var reportErr *ReportError
+		if !errors.As(err, &reportErr) {
+			t.Fatalf("expected a *ReportError, got %v", err)
+		}
+		want := ReportError{
+			StatusCode: 400,
+			Type:       "ReportDefinitionError.INVALID_FIELD_NAME_FOR_REPORT",
+			Trigger:    "Clickz",
+			FieldPath:  "selector.fields",
+		}
+		if *reportErr != want || reportErr.Temporary() {
+			t.Errorf("unexpected error %#v", reportErr)
+		}
+	}
+
+	_, err := NewAWQLClient(&auth).Download(AWQLRequest{
+		Query:  "SELECT Broken FROM CAMPAIGN_PERFORMANCE_REPORT",
+		Format: DownloadFormatCSVForExcel,
+	})
+	var reportErr *ReportError
+	if !errors.As(err, &reportErr) || !reportErr.Temporary() || reportErr.Body != "<html><body>The server encount" {
+		t.Errorf("unexpected error %#v", err)
+	}
+
+	if _, err := NewAWQLClient(&auth).Download(AWQLRequest{Query: "SELECT Id FROM CAMPAIGN_PERFORMANCE_REPORT"}); err != ErrInvalidReportDownloadType {
+		t.Errorf("expected an invalid format error, got %v", err)
+	}
+}
+
+func TestReportHeaders(t *testing.T) {
+	var headers []http.Header
+	var forms []string
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		headers = append(headers, r.Header)
+		forms = append(forms, string(body))
+		fmt.Fprint(w, "1,2\n")
+	})
+	auth.Client.Timeout = 10 * time.Minute
+
+	def := testAccountReportDefinition
+	def.DownloadFormat = DownloadFormatCSVForExcel
+	def.SkipColumnHeader = true
+	def.UseRawEnumValues = true
+	def.SkipSummary = true
+	if _, err := NewReportDefinitionService(&auth).Request(&def); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewAWQLClient(&auth).Download(AWQLRequest{
+		Query:             "SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT",
+		Format:            AWQLFormatCSVForExcel,
+		SkipColumnHeader:  true,
+		UseRawEnumValues:  true,
+		SkipReportSummary: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(forms[0], "CSVFOREXCEL") || !strings.Contains(forms[1], "__fmt=CSVFOREXCEL") {
+		t.Errorf("format not sent as expected: %v", forms)
+	}
+	for i, h := range headers {
+		for name, want := range map[string]string{
+			"skipReportHeader":       "false",
+			"skipColumnHeader":       "true",
+			"skipReportSummary":      "true",
+			"includeZeroImpressions": "false",
+			"useRawEnumValues":       "true",
+			"clientCustomerId":       "123-456-7890",
+		} {
+			if got := h.Get(name); got != want {
+				t.Errorf("request %d: expected %s %q, got %q", i, name, want, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package gads
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ReportColumnBehavior tells how a report column aggregates the rows.
+type ReportColumnBehavior string
+
+// Report column behaviors
+const (
+	// ReportAttribute columns describe the entity the row is about.
+	ReportAttribute ReportColumnBehavior = "ATTRIBUTE"
+	// ReportSegment columns split the stats of an entity in several rows.
+	ReportSegment ReportColumnBehavior = "SEGMENT"
+	// ReportMetric columns hold the stats.
+	ReportMetric ReportColumnBehavior = "METRIC"
+)
+
+// ReportColumn describes a column, or field, of a report type.
+type ReportColumn struct {
+	Name        string // name used in selectors and AWQL queries
+	DisplayName string // name used in the downloaded column header
+	Type        string // Long, Integer, Double, Money, String, Date or Enum
+	Behavior    ReportColumnBehavior
+	Filterable  bool
+	// IncompatibleWith lists the columns that can't be selected, or
+	// filtered on, along with this one.
+	IncompatibleWith []string
+}
+
+// ReportTypeInfo describes the columns of a report type.
+type ReportTypeInfo struct {
+	Name    string
+	Columns []ReportColumn
+	// Complete is set when Columns lists all the columns of the report
+	// type, ReportDefinition.ValidRequest then rejects the columns missing
+	// from it too. The columns missing from the other report types are
+	// only rejected by an explicit call to Validate.
+	Complete bool
+	byName   map[string]int
+}
+
+// Column returns the column of the report named name.
+func (rt *ReportTypeInfo) Column(name string) (ReportColumn, bool) {
+	if i, ok := rt.byName[name]; ok {
+		return rt.Columns[i], true
+	}
+	return ReportColumn{}, false
+}
+
+// Validate checks the selector of a report definition against the
+// columns of the report type. All the problems found are joined in the
+// error. Unless the report type is Complete, a column missing from the
+// catalog may still be accepted by the api.
+func (rt *ReportTypeInfo) Validate(selector Selector) error {
+	return rt.validate(selector, true)
+}
+
+// validate is Validate, the columns missing from the catalog are only
+// rejected when strict, the others are always checked.
+func (rt *ReportTypeInfo) validate(selector Selector, strict bool) error {
+	errs := []error{validateReportSelector(selector)}
+
+	used := map[string]bool{}
+	var names []string
+	use := func(name string) {
+		if !used[name] {
+			used[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, f := range selector.Fields {
+		if _, ok := rt.byName[f]; !ok {
+			if strict {
+				errs = append(errs, fmt.Errorf("gads: %q is not a column of %s", f, rt.Name))
+			}
+			continue
+		}
+		use(f)
+	}
+	for _, p := range selector.Predicates {
+		c, ok := rt.Column(p.Field)
+		switch {
+		case !ok:
+			if strict {
+				errs = append(errs, fmt.Errorf("gads: %q is not a column of %s", p.Field, rt.Name))
+			}
+			continue
+		case !c.Filterable:
+			errs = append(errs, fmt.Errorf("gads: %q can't be filtered on in %s", p.Field, rt.Name))
+		}
+		use(p.Field)
+	}
+
+	// report each incompatible pair once, in the selector order
+	for i, a := range names {
+		c, _ := rt.Column(a)
+		for _, b := range names[i+1:] {
+			if containsField(c.IncompatibleWith, b) {
+				errs = append(errs, fmt.Errorf("gads: %q and %q are incompatible in %s: %s",
+					a, b, rt.Name, incompatibilityReason(c, rt.Columns[rt.byName[b]])))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateReportSelector checks what the api rejects in the selector of
+// any report, whatever its columns.
+func validateReportSelector(selector Selector) error {
+	var errs []error
+	if len(selector.Fields) == 0 {
+		errs = append(errs, errors.New("gads: report selector without fields"))
+	}
+	for _, p := range selector.Predicates {
+		errs = append(errs, validatePredicate(p))
+	}
+	if len(selector.Ordering) > 0 {
+		errs = append(errs, errors.New("gads: reports can't be ordered, sort the rows once downloaded"))
+	}
+	if selector.Paging != nil {
+		errs = append(errs, errors.New("gads: reports can't be paged, they are downloaded whole"))
+	}
+	if r := selector.DateRange; r != nil && time.Time(r.Max).Before(time.Time(r.Min)) {
+		errs = append(errs, errors.New("gads: date range ends before it starts"))
+	}
+	return errors.Join(errs...)
+}
+
+// incompatibilityReason explains why a and b can't be used together.
+func incompatibilityReason(a, b ReportColumn) string {
+	if a.Behavior == ReportMetric {
+		a, b = b, a
+	}
+	if a.Behavior == ReportSegment && b.Behavior == ReportMetric {
+		return fmt.Sprintf("the %s segment only applies to conversion metrics, not to %s", a.Name, b.Name)
+	}
+	return fmt.Sprintf("%s and %s segment the stats in ways that can't be combined", a.Name, b.Name)
+}
+
+// ReportTypes is the catalog of the report types known to the api. The
+// columns are only described, in part, for the most used ones, the others
+// have no columns.
+var ReportTypes = map[string]*ReportTypeInfo{}
+
+// LookupReportType returns the catalog entry of the report type named name.
+func LookupReportType(name string) (*ReportTypeInfo, bool) {
+	rt, ok := ReportTypes[name]
+	return rt, ok
+}
+
+// ReportTypeNames returns the sorted names of the known report types.
+func ReportTypeNames() []string {
+	names := make([]string, 0, len(ReportTypes))
+	for n := range ReportTypes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// report types without column metadata
+var reportTypeNames = []string{
+	"AD_CUSTOMIZERS_FEED_ITEM_REPORT",
+	"AGE_RANGE_PERFORMANCE_REPORT",
+	"AUDIENCE_PERFORMANCE_REPORT",
+	"AUTOMATIC_PLACEMENTS_PERFORMANCE_REPORT",
+	"BID_GOAL_PERFORMANCE_REPORT",
+	"BUDGET_PERFORMANCE_REPORT",
+	"CALL_METRICS_CALL_DETAILS_REPORT",
+	"CAMPAIGN_AD_SCHEDULE_TARGET_REPORT",
+	"CAMPAIGN_CRITERIA_REPORT",
+	"CAMPAIGN_GROUP_PERFORMANCE_REPORT",
+	"CAMPAIGN_LOCATION_TARGET_REPORT",
+	"CAMPAIGN_NEGATIVE_KEYWORDS_PERFORMANCE_REPORT",
+	"CAMPAIGN_NEGATIVE_LOCATIONS_REPORT",
+	"CAMPAIGN_NEGATIVE_PLACEMENTS_PERFORMANCE_REPORT",
+	"CAMPAIGN_SHARED_SET_REPORT",
+	"CLICK_PERFORMANCE_REPORT",
+	"CREATIVE_CONVERSION_REPORT",
+	"CRITERIA_PERFORMANCE_REPORT",
+	"DISPLAY_KEYWORD_PERFORMANCE_REPORT",
+	"DISPLAY_TOPICS_PERFORMANCE_REPORT",
+	"FINAL_URL_REPORT",
+	"GENDER_PERFORMANCE_REPORT",
+	"GEO_PERFORMANCE_REPORT",
+	"INCOME_RANGE_PERFORMANCE_REPORT",
+	"KEYWORDLESS_CATEGORY_REPORT",
+	"KEYWORDLESS_QUERY_REPORT",
+	"LABEL_REPORT",
+	"LANDING_PAGE_REPORT",
+	"PAID_ORGANIC_QUERY_REPORT",
+	"PARENTAL_STATUS_PERFORMANCE_REPORT",
+	"PLACEHOLDER_FEED_ITEM_REPORT",
+	"PLACEHOLDER_REPORT",
+	"PLACEMENT_PERFORMANCE_REPORT",
+	"PRODUCT_PARTITION_REPORT",
+	"SHARED_SET_CRITERIA_REPORT",
+	"SHARED_SET_REPORT",
+	"SHOPPING_PERFORMANCE_REPORT",
+	"TOP_CONTENT_PERFORMANCE_REPORT",
+	"URL_PERFORMANCE_REPORT",
+	"USER_AD_DISTANCE_REPORT",
+	"VIDEO_PERFORMANCE_REPORT",
+}
+
+func attributeColumn(name, display, typ string) ReportColumn {
+	return ReportColumn{Name: name, DisplayName: display, Type: typ, Behavior: ReportAttribute, Filterable: true}
+}
+
+func segmentColumn(name, display, typ string) ReportColumn {
+	return ReportColumn{Name: name, DisplayName: display, Type: typ, Behavior: ReportSegment, Filterable: true}
+}
+
+func metricColumn(name, display, typ string) ReportColumn {
+	return ReportColumn{Name: name, DisplayName: display, Type: typ, Behavior: ReportMetric, Filterable: true}
+}
+
+func unfilterable(c ReportColumn) ReportColumn {
+	c.Filterable = false
+	return c
+}
+
+var (
+	accountColumns = []ReportColumn{
+		unfilterable(attributeColumn("AccountDescriptiveName", "Account", "String")),
+		attributeColumn("ExternalCustomerId", "Customer ID", "Long"),
+		unfilterable(attributeColumn("AccountCurrencyCode", "Currency", "String")),
+	}
+	campaignColumns = []ReportColumn{
+		attributeColumn("CampaignId", "Campaign ID", "Long"),
+		attributeColumn("CampaignName", "Campaign", "String"),
+		attributeColumn("CampaignStatus", "Campaign state", "Enum"),
+		attributeColumn("AdvertisingChannelType", "Advertising Channel", "Enum"),
+		attributeColumn("BiddingStrategyType", "Bid Strategy Type", "Enum"),
+	}
+	adGroupColumns = []ReportColumn{
+		attributeColumn("AdGroupId", "Ad group ID", "Long"),
+		attributeColumn("AdGroupName", "Ad group", "String"),
+		attributeColumn("AdGroupStatus", "Ad group state", "Enum"),
+	}
+	dateSegments = []ReportColumn{
+		segmentColumn("Date", "Day", "Date"),
+		segmentColumn("Week", "Week", "Date"),
+		segmentColumn("Month", "Month", "Date"),
+		segmentColumn("DayOfWeek", "Day of week", "Enum"),
+	}
+	trafficSegments = []ReportColumn{
+		segmentColumn("Device", "Device", "Enum"),
+		segmentColumn("AdNetworkType1", "Network", "Enum"),
+		segmentColumn("AdNetworkType2", "Network (with search partners)", "Enum"),
+	}
+	hourSegment  = segmentColumn("HourOfDay", "Hour of day", "Integer")
+	slotSegment  = segmentColumn("Slot", "Top vs. Other", "Enum")
+	clickSegment = segmentColumn("ClickType", "Click type", "Enum")
+
+	// the conversion segments split the conversions by action, they can't
+	// be used with the metrics counting something else.
+	conversionSegments = []ReportColumn{
+		segmentColumn("ConversionTypeName", "Conversion name", "String"),
+		segmentColumn("ConversionCategoryName", "Conversion category", "String"),
+		segmentColumn("ConversionTrackerId", "Conversion Tracker Id", "Long"),
+		segmentColumn("ExternalConversionSource", "Conversion source", "Enum"),
+	}
+	conversionMetrics = []ReportColumn{
+		metricColumn("Conversions", "Conversions", "Double"),
+		metricColumn("ConversionValue", "Total conv. value", "Double"),
+		metricColumn("AllConversions", "All conv.", "Double"),
+		metricColumn("AllConversionValue", "All conv. value", "Double"),
+		metricColumn("ValuePerConversion", "Value / conv.", "Double"),
+	}
+	trafficMetrics = []ReportColumn{
+		metricColumn("Impressions", "Impressions", "Long"),
+		metricColumn("Clicks", "Clicks", "Long"),
+		metricColumn("Interactions", "Interactions", "Long"),
+		metricColumn("Cost", "Cost", "Money"),
+		metricColumn("Ctr", "CTR", "Double"),
+		metricColumn("AverageCpc", "Avg. CPC", "Money"),
+		metricColumn("AverageCpm", "Avg. CPM", "Money"),
+		metricColumn("AverageCost", "Avg. Cost", "Money"),
+		metricColumn("AveragePosition", "Avg. position", "Double"),
+		metricColumn("CostPerConversion", "Cost / conv.", "Money"),
+		metricColumn("ConversionRate", "Conv. rate", "Double"),
+		metricColumn("VideoViews", "Views", "Long"),
+	}
+	impressionShareMetrics = []ReportColumn{
+		metricColumn("SearchImpressionShare", "Search Impr. share", "Double"),
+		metricColumn("SearchRankLostImpressionShare", "Search Lost IS (rank)", "Double"),
+	}
+)
+
+func init() {
+	for _, name := range reportTypeNames {
+		addReportType(name)
+	}
+
+	addReportType("ACCOUNT_PERFORMANCE_REPORT",
+		accountColumns, dateSegments, trafficSegments,
+		[]ReportColumn{hourSegment, slotSegment, clickSegment}, conversionSegments,
+		trafficMetrics, impressionShareMetrics, conversionMetrics,
+	)
+	addReportType("CAMPAIGN_PERFORMANCE_REPORT",
+		accountColumns, campaignColumns,
+		[]ReportColumn{
+			attributeColumn("BudgetId", "Budget ID", "Long"),
+			attributeColumn("Amount", "Budget", "Money"),
+		},
+		dateSegments, trafficSegments,
+		[]ReportColumn{hourSegment, slotSegment, clickSegment}, conversionSegments,
+		trafficMetrics, impressionShareMetrics, conversionMetrics,
+	)
+	addReportType("ADGROUP_PERFORMANCE_REPORT",
+		accountColumns, campaignColumns, adGroupColumns,
+		[]ReportColumn{attributeColumn("CpcBid", "Default max. CPC", "Money")},
+		dateSegments, trafficSegments,
+		[]ReportColumn{hourSegment, slotSegment, clickSegment}, conversionSegments,
+		trafficMetrics, impressionShareMetrics, conversionMetrics,
+	)
+	addReportType("AD_PERFORMANCE_REPORT",
+		accountColumns, campaignColumns, adGroupColumns,
+		[]ReportColumn{
+			attributeColumn("Id", "Ad ID", "Long"),
+			attributeColumn("AdType", "Ad type", "Enum"),
+			attributeColumn("Status", "Ad state", "Enum"),
+			attributeColumn("HeadlinePart1", "Headline 1", "String"),
+			attributeColumn("HeadlinePart2", "Headline 2", "String"),
+			attributeColumn("Description", "Description", "String"),
+		},
+		dateSegments, trafficSegments,
+		[]ReportColumn{slotSegment, clickSegment}, conversionSegments,
+		trafficMetrics, conversionMetrics,
+	)
+	addReportType("KEYWORDS_PERFORMANCE_REPORT",
+		accountColumns, campaignColumns, adGroupColumns,
+		[]ReportColumn{
+			attributeColumn("Id", "Keyword ID", "Long"),
+			attributeColumn("Criteria", "Keyword", "String"),
+			attributeColumn("KeywordMatchType", "Match type", "Enum"),
+			attributeColumn("Status", "Keyword state", "Enum"),
+			attributeColumn("CpcBid", "Max. CPC", "Money"),
+			attributeColumn("QualityScore", "Quality score", "Integer"),
+		},
+		dateSegments, trafficSegments,
+		[]ReportColumn{slotSegment, clickSegment}, conversionSegments,
+		trafficMetrics, impressionShareMetrics, conversionMetrics,
+	)
+	addReportType("SEARCH_QUERY_PERFORMANCE_REPORT",
+		accountColumns, campaignColumns, adGroupColumns,
+		[]ReportColumn{
+			attributeColumn("Query", "Search term", "String"),
+			attributeColumn("QueryMatchTypeWithVariant", "Match type", "Enum"),
+			attributeColumn("KeywordId", "Keyword ID", "Long"),
+			attributeColumn("KeywordTextMatchingQuery", "Keyword", "String"),
+			attributeColumn("CreativeId", "Ad ID", "Long"),
+		},
+		dateSegments, trafficSegments, conversionSegments,
+		trafficMetrics, conversionMetrics,
+	)
+}
+
+// addReportType adds a report type to the catalog, marking the
+// conversion segments incompatible with the metrics they don't split.
+func addReportType(name string, groups ...[]ReportColumn) {
+	rt := &ReportTypeInfo{Name: name, byName: map[string]int{}}
+	for _, g := range groups {
+		for _, c := range g {
+			rt.byName[c.Name] = len(rt.Columns)
+			rt.Columns = append(rt.Columns, c)
+		}
+	}
+
+	var segments, metrics []string
+	for _, c := range conversionSegments {
+		if _, ok := rt.byName[c.Name]; ok {
+			segments = append(segments, c.Name)
+		}
+	}
+	for _, c := range rt.Columns {
+		if c.Behavior == ReportMetric && !containsColumn(conversionMetrics, c.Name) {
+			metrics = append(metrics, c.Name)
+		}
+	}
+	for i := range rt.Columns {
+		c := &rt.Columns[i]
+		switch {
+		case containsField(segments, c.Name):
+			c.IncompatibleWith = metrics
+		case containsField(metrics, c.Name):
+			c.IncompatibleWith = segments
+		}
+	}
+	ReportTypes[name] = rt
+}
+
+func containsColumn(columns []ReportColumn, name string) bool {
+	for _, c := range columns {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
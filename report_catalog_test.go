@@ -0,0 +1,141 @@
+package gads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReportCatalog(t *testing.T) {
+	rt, ok := LookupReportType("KEYWORDS_PERFORMANCE_REPORT")
+	if !ok {
+		t.Fatal("KEYWORDS_PERFORMANCE_REPORT missing from the catalog")
+	}
+	c, ok := rt.Column("Impressions")
+	if !ok || c.Behavior != ReportMetric || c.Type != "Long" || !c.Filterable {
+		t.Errorf("unexpected Impressions column %#v", c)
+	}
+	if !containsField(c.IncompatibleWith, "ConversionTypeName") {
+		t.Errorf("expected Impressions to be incompatible with ConversionTypeName, got %v", c.IncompatibleWith)
+	}
+	if c, _ := rt.Column("Conversions"); len(c.IncompatibleWith) != 0 {
+		t.Errorf("expected Conversions to be compatible with every column, got %v", c.IncompatibleWith)
+	}
+	if _, ok := rt.Column("HourOfDay"); ok {
+		t.Error("the keywords report has no HourOfDay segment")
+	}
+
+	names := ReportTypeNames()
+	if len(names) != 47 || names[0] != "ACCOUNT_PERFORMANCE_REPORT" {
+		t.Errorf("unexpected report types %v", names)
+	}
+}
+
+func TestReportValidRequest(t *testing.T) {
+	def := ReportDefinition{
+		ReportName:     "campaigns",
+		ReportType:     "CAMPAIGN_PERFORMANCE_REPORT",
+		DownloadFormat: DownloadFormatCSV,
+		Selector: Selector{
+			Fields: []string{"CampaignId", "Date", "ConversionTypeName", "Conversions"},
+			Predicates: []Predicate{
+				{"CampaignStatus", "IN", []string{"ENABLED", "PAUSED"}},
+			},
+		},
+	}
+	if err := def.ValidRequest(); err != nil {
+		t.Fatal(err)
+	}
+
+	// columns missing from a partial catalog are left to the api
+	def.Selector.Fields = append(def.Selector.Fields, "Labels", "SearchBudgetLostImpressionShare")
+	if err := def.ValidRequest(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	keywords := ReportDefinition{ReportName: "keywords", ReportType: "KEYWORDS_PERFORMANCE_REPORT", DownloadFormat: DownloadFormatCSV,
+		Selector: Selector{Fields: []string{"Id", "FirstPageCpc", "FinalUrls"}}}
+	if err := keywords.ValidRequest(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	def.Selector.Fields = append(def.Selector.Fields, "Clicks", "Stauts")
+	def.Selector.Predicates = append(def.Selector.Predicates, Predicate{"AccountDescriptiveName", "EQUALS", []string{"a"}})
+	def.Selector.Ordering = []OrderBy{{"Clicks", "DESCENDING"}}
+	err := def.ValidRequest()
+	if err == nil {
+		t.Fatal("expected an invalid request")
+	}
+	// the known columns are checked, the unknown ones left to the api
+	for _, want := range []string{
+		`"ConversionTypeName" and "Clicks" are incompatible`,
+		`"AccountDescriptiveName" can't be filtered on`,
+		"reports can't be ordered",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in the error, got\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "Stauts") {
+		t.Errorf("unexpected unknown column error %v", err)
+	}
+	err = def.Validate()
+	if err == nil {
+		t.Fatal("expected an invalid request")
+	}
+	for _, want := range []string{
+		`"Stauts" is not a column of CAMPAIGN_PERFORMANCE_REPORT`,
+		`"ConversionTypeName" and "Clicks" are incompatible`,
+		"only applies to conversion metrics, not to Clicks",
+		`"AccountDescriptiveName" can't be filtered on`,
+		"reports can't be ordered",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in the error, got\n%v", want, err)
+		}
+	}
+
+	// a complete catalog rejects the unknown columns in ValidRequest
+	rt, _ := LookupReportType("CAMPAIGN_PERFORMANCE_REPORT")
+	complete := *rt
+	complete.Complete = true
+	if err := def.validColumns(&complete, false); err == nil || !strings.Contains(err.Error(), `"Stauts" is not a column`) {
+		t.Errorf("expected an unknown column error, got %v", err)
+	}
+
+	unknown := ReportDefinition{ReportName: "x", ReportType: "CAMPAIGN_REPORT", DownloadFormat: DownloadFormatCSV}
+	if err := unknown.ValidRequest(); !errors.Is(err, ErrUnknownReportType) {
+		t.Errorf("expected an unknown report type error, got %v", err)
+	}
+	// types without column metadata are only checked by name
+	label := ReportDefinition{ReportName: "x", ReportType: "LABEL_REPORT", DownloadFormat: DownloadFormatCSV,
+		Selector: Selector{Fields: []string{"LabelId"}}}
+	if err := label.ValidRequest(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	income := ReportDefinition{ReportName: "x", ReportType: "INCOME_RANGE_PERFORMANCE_REPORT", DownloadFormat: DownloadFormatCSV,
+		Selector: Selector{Fields: []string{"Criteria", "Impressions"}}}
+	if err := income.ValidRequest(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestReportDecoderFieldNames(t *testing.T) {
+	report := "\"KEYWORDS_PERFORMANCE_REPORT (Jan 1, 2019-Jan 1, 2019)\"\n" +
+		"Keyword ID,Match type,Impressions\n" +
+		"42,Exact,7\n"
+	dec, err := NewReportDecoder(strings.NewReader(report), DownloadFormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var row struct {
+		Id               int64
+		KeywordMatchType string
+		Impressions      int64
+	}
+	if err := dec.Decode(&row); err != nil {
+		t.Fatal(err)
+	}
+	if row.Id != 42 || row.KeywordMatchType != "Exact" || row.Impressions != 7 {
+		t.Errorf("unexpected row %#v", row)
+	}
+}
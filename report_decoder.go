@@ -0,0 +1,678 @@
+package gads
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ErrNoReportSummary is returned by ReportDecoder.Summary when the report
+// had no summary row, or has not been read to its end yet.
+var ErrNoReportSummary = errors.New("gads: no report summary")
+
+// reportHeaderPattern matches the first line of a report downloaded with its
+// report header, like "CAMPAIGN_PERFORMANCE_REPORT (Jan 1, 2019-Jan 31, 2019)".
+var reportHeaderPattern = regexp.MustCompile(`^[A-Z0-9_]+ \(.*\)$`)
+
+// report date layouts, tried in order
+var reportDateLayouts = []string{"2006-01-02", "2006-01-02 15:04:05", "20060102", "2006-01"}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// ReportDecoder reads the rows of a report, as returned by
+// ReportDefinitionService.Request or AWQLClient.Download, one at a time
+// into structs.
+//
+// CSV, CSVFOREXCEL, TSV and XML reports are supported, gzipped reports are
+// decompressed transparently whatever the format says. The report header and the
+// summary row are recognised and kept aside.
+//
+// Struct fields are matched with the columns by their report tag, or
+// their name when they have none. A tag lists the names the column may
+// have, separated by |, then options after a comma. Names are compared
+// ignoring case, spaces and punctuation so "CampaignId", "Campaign ID"
+// and "campaignID" all match the same column.
+//
+//   type CampaignStats struct {
+//     CampaignId  int64     `report:"CampaignId"`
+//     Day         time.Time `report:"Date|Day"`
+//     Impressions int64     `report:"Impressions|Impr."`
+//     Cost        float64   `report:"Cost,micros"`
+//     Ctr         float64   `report:"Ctr"`
+//     Status      *string   `report:"CampaignStatus|Campaign state"`
+//   }
+//
+//   dec, err := gads.NewReportDecoder(body, gads.DownloadFormatCSVGzipped)
+//   for {
+//     var row CampaignStats
+//     err := dec.Decode(&row)
+//     if err == io.EOF {
+//       break
+//     }
+//     ...
+//   }
+//
+// Values are parsed according to the field type:
+//   - "--" and " --" placeholders leave the field zero, or nil for pointers
+//   - integers and floats accept thousands separators
+//   - floats with the micros option are divided by 1,000,000
+//   - percentages like "12.34%", "< 10%" or "> 90%" are decoded as fractions
+//   - time.Time accepts yyyy-MM-dd dates, with or without time
+//   - types implementing encoding.TextUnmarshaler decode themselves, which
+//     suits enums
+//
+// Fields whose column is missing from the report are left untouched.
+type ReportDecoder struct {
+	// Columns names the columns, in order, of reports downloaded without
+	// their column header. It must be set before the first Decode.
+	Columns []string
+
+	// ReportType lets the struct tags use the api field names, like
+	// "Impressions", for reports whose column header has display names,
+	// like "Impr.". It is read from the report header when there is one.
+	ReportType string
+
+	format  DownloadFormat
+	csv     *csv.Reader
+	xml     *xml.Decoder
+	started bool
+	row     int
+
+	header  string
+	columns [][]string // normalized names of every column
+	info    []ReportColumn
+	byName  map[string]int
+	next    []string
+	summary []string
+	plans   map[reflect.Type][]reportFieldPlan
+}
+
+// gunzipReport decompresses r when it starts with the gzip magic number,
+// whatever the download format says.
+func gunzipReport(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
+
+// excelReport prepares a CSVFOREXCEL report for the csv reader: it is
+// converted to UTF-8 when it starts with a UTF-16 byte order mark and its
+// separator is guessed from its first lines, Excel using tabs with UTF-16.
+func excelReport(r io.Reader) (io.Reader, rune, error) {
+	br := bufio.NewReader(r)
+	if bom, err := br.Peek(2); err == nil && bom[0] == 0xff && bom[1] == 0xfe {
+		br.Discard(2)
+		br = bufio.NewReader(&utf16Reader{r: br})
+	} else if bom, err := br.Peek(3); err == nil && bom[0] == 0xef && bom[1] == 0xbb && bom[2] == 0xbf {
+		br.Discard(3)
+	}
+	head, err := br.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, 0, err
+	}
+	if strings.ContainsRune(string(head), '\t') {
+		return br, '\t', nil
+	}
+	return br, ',', nil
+}
+
+// utf16Reader converts little endian UTF-16 to UTF-8.
+type utf16Reader struct {
+	r   *bufio.Reader
+	buf []byte
+}
+
+func (u *utf16Reader) Read(p []byte) (int, error) {
+	for len(u.buf) == 0 {
+		var unit [2]byte
+		if _, err := io.ReadFull(u.r, unit[:]); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				err = errors.New("gads: truncated UTF-16 report")
+			}
+			return 0, err
+		}
+		c := rune(binary.LittleEndian.Uint16(unit[:]))
+		if utf16.IsSurrogate(c) {
+			var low [2]byte
+			if _, err := io.ReadFull(u.r, low[:]); err != nil {
+				return 0, errors.New("gads: truncated UTF-16 report")
+			}
+			c = utf16.DecodeRune(c, rune(binary.LittleEndian.Uint16(low[:])))
+		}
+		u.buf = utf8.AppendRune(u.buf, c)
+	}
+	n := copy(p, u.buf)
+	u.buf = u.buf[n:]
+	return n, nil
+}
+
+// NewReportDecoder creates a decoder reading a report downloaded in format.
+func NewReportDecoder(r io.Reader, format DownloadFormat) (*ReportDecoder, error) {
+	r, err := gunzipReport(r)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &ReportDecoder{format: format, plans: map[reflect.Type][]reportFieldPlan{}}
+	switch format {
+	case DownloadFormatXML, DownloadFormatXMLGzipped:
+		d.xml = xml.NewDecoder(r)
+	case DownloadFormatCSV, DownloadFormatCSVGzipped, DownloadFormatTSV, DownloadFormatCSVForExcel:
+		comma := ','
+		if format == DownloadFormatTSV {
+			comma = '\t'
+		}
+		if format == DownloadFormatCSVForExcel {
+			if r, comma, err = excelReport(r); err != nil {
+				return nil, err
+			}
+		}
+		d.csv = csv.NewReader(r)
+		d.csv.Comma = comma
+		d.csv.FieldsPerRecord = -1
+		d.csv.LazyQuotes = true
+	default:
+		return nil, fmt.Errorf("gads: cannot decode %q reports", format)
+	}
+	return d, nil
+}
+
+// Header returns the report header line, empty if the report was
+// downloaded without it.
+func (d *ReportDecoder) Header() string {
+	return d.header
+}
+
+// Decode reads the next row of the report into v, a pointer to a struct.
+// It returns io.EOF after the last row.
+func (d *ReportDecoder) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("gads: Decode expects a pointer to a struct, got %T", v)
+	}
+	record, err := d.read()
+	if err != nil {
+		return err
+	}
+	return d.decodeRecord(record, rv.Elem())
+}
+
+// DecodeValues reads the next row of the report as values typed after
+// ReportColumns: int64 for Long, Integer and Money columns, Money staying
+// in micros, float64 for Double, time.Time for Date and string for the
+// others. Null placeholders are returned as nil. It returns io.EOF after
+// the last row.
+func (d *ReportDecoder) DecodeValues() ([]interface{}, error) {
+	record, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(d.info))
+	for i, c := range d.info {
+		if i >= len(record) {
+			break
+		}
+		if values[i], err = parseReportValue(c.Type, record[i]); err != nil {
+			return nil, fmt.Errorf("gads: report row %d, column %s: %w", d.row, c.Name, err)
+		}
+	}
+	return values, nil
+}
+
+// ReportColumns describes the columns of the report, in order, once the
+// first row has been read by Decode or DecodeValues. Columns found in the
+// ReportTypes catalog get its metadata, the others are described as
+// String columns named after the report header.
+func (d *ReportDecoder) ReportColumns() []ReportColumn {
+	return d.info
+}
+
+// describeColumns finds the catalog entry of every column.
+func (d *ReportDecoder) describeColumns(names [][]string, raw []string) []ReportColumn {
+	rt, _ := LookupReportType(d.reportType())
+
+	info := make([]ReportColumn, len(names))
+	for i, aliases := range names {
+		info[i] = ReportColumn{Type: "String"}
+		if i < len(raw) {
+			info[i].Name, info[i].DisplayName = raw[i], raw[i]
+		}
+		if rt == nil {
+			continue
+		}
+		for _, c := range rt.Columns {
+			if containsField(aliases, normalizeColumn(c.Name)) {
+				info[i] = c
+				break
+			}
+		}
+	}
+	return info
+}
+
+// Summary decodes the summary row of the report into v, leaving the field
+// of its first column zero. It is only available once Decode returned
+// io.EOF.
+func (d *ReportDecoder) Summary(v interface{}) error {
+	if d.summary == nil {
+		return ErrNoReportSummary
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("gads: Summary expects a pointer to a struct, got %T", v)
+	}
+	// the first column holds the "Total" label
+	summary := append([]string{""}, d.summary[1:]...)
+	return d.decodeRecord(summary, rv.Elem())
+}
+
+func (d *ReportDecoder) setColumns(names [][]string, raw []string) {
+	d.addFieldNames(names)
+	d.columns = names
+	d.info = d.describeColumns(names, raw)
+	d.byName = map[string]int{}
+	for i, aliases := range names {
+		for _, n := range aliases {
+			if _, ok := d.byName[n]; !ok {
+				d.byName[n] = i
+			}
+		}
+	}
+}
+
+// reportType returns the type of the report, set by the user or read from
+// the report header.
+func (d *ReportDecoder) reportType() string {
+	if d.ReportType != "" {
+		return d.ReportType
+	}
+	reportType, _, _ := strings.Cut(d.header, " ")
+	return reportType
+}
+
+// addFieldNames adds the api name of the columns of the report type to
+// their aliases, matching them by display name.
+func (d *ReportDecoder) addFieldNames(names [][]string) {
+	rt, ok := LookupReportType(d.reportType())
+	if !ok {
+		return
+	}
+	for _, c := range rt.Columns {
+		display := normalizeColumn(c.DisplayName)
+		for i, aliases := range names {
+			if aliases[0] == display || (len(aliases) > 1 && aliases[1] == display) {
+				names[i] = append(aliases, normalizeColumn(c.Name))
+			}
+		}
+	}
+}
+
+func (d *ReportDecoder) read() ([]string, error) {
+	if d.xml != nil {
+		return d.readXML()
+	}
+	return d.readCSV()
+}
+
+// readCSV returns the next data record, keeping one record ahead to
+// recognise the summary row.
+func (d *ReportDecoder) readCSV() ([]string, error) {
+	if !d.started {
+		d.started = true
+		first, err := d.csv.Read()
+		if err != nil {
+			return nil, err
+		}
+		if len(first) == 1 && reportHeaderPattern.MatchString(first[0]) {
+			d.header = first[0]
+			if first, err = d.csv.Read(); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(d.Columns) > 0 {
+			d.setColumns(normalizeColumns(d.Columns), d.Columns)
+			if !sameColumns(d.columns, first) {
+				d.next = first
+			}
+		} else {
+			d.setColumns(normalizeColumns(first), first)
+		}
+		if d.next == nil {
+			if d.next, err = d.readRecord(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	record := d.next
+	if record == nil {
+		return nil, io.EOF
+	}
+	next, err := d.readRecord()
+	if err != nil {
+		return nil, err
+	}
+	d.next = next
+	if next == nil && len(record) > 0 && strings.TrimSpace(record[0]) == "Total" {
+		d.summary = record
+		return nil, io.EOF
+	}
+	d.row++
+	return record, nil
+}
+
+// readRecord reads a csv record, nil at the end of the report.
+func (d *ReportDecoder) readRecord() ([]string, error) {
+	record, err := d.csv.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	return record, err
+}
+
+func (d *ReportDecoder) readXML() ([]string, error) {
+	var columns [][]string
+	var raw []string
+	for {
+		tok, err := d.xml.Token()
+		if err == io.EOF && d.byName == nil && len(columns) > 0 {
+			// a report without rows
+			d.setColumns(columns, raw)
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "report-name":
+			d.header = xmlAttr(start, "name")
+		case "column":
+			columns = append(columns, []string{
+				normalizeColumn(xmlAttr(start, "name")),
+				normalizeColumn(xmlAttr(start, "display")),
+			})
+			raw = append(raw, xmlAttr(start, "name"))
+		case "row":
+			if d.byName == nil {
+				d.setColumns(columns, raw)
+			}
+			record := make([]string, len(d.columns))
+			for _, a := range start.Attr {
+				if i, ok := d.byName[normalizeColumn(a.Name.Local)]; ok {
+					record[i] = a.Value
+				}
+			}
+			d.row++
+			return record, nil
+		}
+	}
+}
+
+func xmlAttr(start xml.StartElement, name string) string {
+	for _, a := range start.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// normalizeColumn lowercases name and drops everything but letters and
+// digits.
+func normalizeColumn(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+func normalizeColumns(names []string) [][]string {
+	columns := make([][]string, len(names))
+	for i, n := range names {
+		columns[i] = []string{normalizeColumn(n)}
+	}
+	return columns
+}
+
+func sameColumns(columns [][]string, record []string) bool {
+	if len(columns) != len(record) {
+		return false
+	}
+	for i, n := range record {
+		if columns[i][0] != normalizeColumn(n) {
+			return false
+		}
+	}
+	return true
+}
+
+// reportFieldPlan maps a struct field to its column.
+type reportFieldPlan struct {
+	index  []int
+	name   string
+	column int
+	micros bool
+}
+
+// reportTags caches the parsed report tags of the struct types.
+var reportTags sync.Map // reflect.Type -> []reportTag
+
+type reportTag struct {
+	index  []int
+	name   string
+	names  []string
+	micros bool
+}
+
+func parseReportTags(t reflect.Type) []reportTag {
+	if tags, ok := reportTags.Load(t); ok {
+		return tags.([]reportTag)
+	}
+	var tags []reportTag
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := f.Tag.Get("report")
+		if tag == "-" {
+			continue
+		}
+		rt := reportTag{index: f.Index, name: f.Name}
+		names, opts := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			names, opts = tag[:i], tag[i+1:]
+		}
+		if names == "" {
+			names = f.Name
+		}
+		for _, n := range strings.Split(names, "|") {
+			rt.names = append(rt.names, normalizeColumn(n))
+		}
+		for _, o := range strings.Split(opts, ",") {
+			if o == "micros" {
+				rt.micros = true
+			}
+		}
+		tags = append(tags, rt)
+	}
+	reportTags.Store(t, tags)
+	return tags
+}
+
+func (d *ReportDecoder) plan(t reflect.Type) []reportFieldPlan {
+	if plan, ok := d.plans[t]; ok {
+		return plan
+	}
+	var plan []reportFieldPlan
+	for _, tag := range parseReportTags(t) {
+		for _, n := range tag.names {
+			if i, ok := d.byName[n]; ok {
+				plan = append(plan, reportFieldPlan{index: tag.index, name: tag.name, column: i, micros: tag.micros})
+				break
+			}
+		}
+	}
+	d.plans[t] = plan
+	return plan
+}
+
+func (d *ReportDecoder) decodeRecord(record []string, v reflect.Value) error {
+	for _, p := range d.plan(v.Type()) {
+		if p.column >= len(record) {
+			continue
+		}
+		if err := setReportValue(v.FieldByIndex(p.index), record[p.column], p.micros); err != nil {
+			return fmt.Errorf("gads: report row %d, field %s: %w", d.row, p.name, err)
+		}
+	}
+	return nil
+}
+
+// isReportNull reports whether raw is one of the placeholders the reports
+// use for missing values.
+func isReportNull(raw string) bool {
+	raw = strings.TrimSpace(raw)
+	return raw == "" || raw == "--"
+}
+
+func setReportValue(f reflect.Value, raw string, micros bool) error {
+	if isReportNull(raw) {
+		f.Set(reflect.Zero(f.Type()))
+		return nil
+	}
+	raw = strings.TrimSpace(raw)
+
+	if f.Kind() == reflect.Ptr {
+		v := reflect.New(f.Type().Elem())
+		if err := setReportValue(v.Elem(), raw, micros); err != nil {
+			return err
+		}
+		f.Set(v)
+		return nil
+	}
+
+	// time.Time is a TextUnmarshaler too, but of RFC 3339 dates only
+	if f.Type() == reflect.TypeOf(time.Time{}) {
+		t, err := parseReportDate(raw)
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if f.CanAddr() && f.Addr().Type().Implements(textUnmarshalerType) {
+		return f.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(raw)
+	case reflect.Bool:
+		switch strings.ToLower(raw) {
+		case "true", "yes":
+			f.SetBool(true)
+		case "false", "no":
+			f.SetBool(false)
+		default:
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.Replace(raw, ",", "", -1), 10, 64)
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.Replace(raw, ",", "", -1), 10, 64)
+		if err != nil {
+			return err
+		}
+		f.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := parseReportFloat(raw)
+		if err != nil {
+			return err
+		}
+		if micros {
+			n /= 1e6
+		}
+		f.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", f.Type())
+	}
+	return nil
+}
+
+func parseReportDate(raw string) (time.Time, error) {
+	for _, layout := range reportDateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", raw)
+}
+
+// parseReportValue parses raw according to the type of a report column:
+// int64 for Long, Integer and Money columns, Money staying in micros,
+// float64 for Double, time.Time for Date and string for the others. The
+// null placeholders are returned as nil.
+func parseReportValue(typ, raw string) (interface{}, error) {
+	if isReportNull(raw) {
+		return nil, nil
+	}
+	raw = strings.TrimSpace(raw)
+	switch typ {
+	case "Long", "Integer", "Money":
+		n, err := strconv.ParseInt(strings.Replace(raw, ",", "", -1), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", raw)
+		}
+		return n, nil
+	case "Double":
+		return parseReportFloat(raw)
+	case "Date":
+		return parseReportDate(raw)
+	}
+	return raw, nil
+}
+
+// parseReportFloat parses numbers and percentages, percentages are
+// returned as fractions.
+func parseReportFloat(raw string) (float64, error) {
+	s := strings.TrimLeft(raw, "<> ")
+	s = strings.Replace(s, ",", "", -1)
+	percent := strings.HasSuffix(s, "%")
+	s = strings.TrimSuffix(s, "%")
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", raw)
+	}
+	if percent {
+		n /= 100
+	}
+	return n, nil
+}
@@ -0,0 +1,182 @@
+package gads
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+)
+
+type testCampaignStatus string
+
+func (s *testCampaignStatus) UnmarshalText(text []byte) error {
+	*s = testCampaignStatus(strings.ToUpper(string(text)))
+	return nil
+}
+
+type testReportRow struct {
+	CampaignId  int64              `report:"CampaignId"`
+	Day         time.Time          `report:"Date|Day"`
+	Impressions int64              `report:"Impressions|Impr."`
+	Cost        float64            `report:"Cost,micros"`
+	Ctr         float64            `report:"Ctr"`
+	Share       *float64           `report:"SearchImpressionShare|Search Impr. share"`
+	Status      testCampaignStatus `report:"CampaignStatus|Campaign state"`
+	Label       *string            `report:"Labels"`
+	Ignored     string             `report:"-"`
+}
+
+const testCSVReport = `"CAMPAIGN_PERFORMANCE_REPORT (Jan 1, 2019-Jan 2, 2019)"
+Campaign ID,Day,Impr.,Cost,CTR,Search Impr. share,Campaign state,Labels
+123,2019-01-01,"1,234",1500000,12.34%,< 10%,enabled, --
+123,2019-01-02,10,0,0.00%,--,enabled,"[""brand""]"
+Total,--,"1,244",1500000,12.24%, --,--,--
+`
+
+func testReportRows(t *testing.T, dec *ReportDecoder) (rows []testReportRow) {
+	t.Helper()
+	for {
+		var row testReportRow
+		err := dec.Decode(&row)
+		if err == io.EOF {
+			return rows
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestReportDecoderCSV(t *testing.T) {
+	dec, err := NewReportDecoder(strings.NewReader(testCSVReport), DownloadFormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := testReportRows(t, dec)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if dec.Header() != "CAMPAIGN_PERFORMANCE_REPORT (Jan 1, 2019-Jan 2, 2019)" {
+		t.Errorf("unexpected header %q", dec.Header())
+	}
+
+	first := rows[0]
+	if first.CampaignId != 123 || first.Impressions != 1234 || first.Cost != 1.5 || first.Status != "ENABLED" {
+		t.Errorf("unexpected row %#v", first)
+	}
+	if !first.Day.Equal(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected day %v", first.Day)
+	}
+	if first.Ctr < 0.12339 || first.Ctr > 0.12341 {
+		t.Errorf("expected a 0.1234 ctr, got %v", first.Ctr)
+	}
+	if first.Share == nil || *first.Share != 0.1 {
+		t.Errorf("expected a 0.1 impression share, got %v", first.Share)
+	}
+	if first.Label != nil {
+		t.Errorf("expected the \" --\" label to be nil, got %q", *first.Label)
+	}
+	if rows[1].Share != nil || rows[1].Label == nil || *rows[1].Label != `["brand"]` {
+		t.Errorf("unexpected row %#v", rows[1])
+	}
+
+	var total testReportRow
+	if err := dec.Summary(&total); err != nil {
+		t.Fatal(err)
+	}
+	if total.Impressions != 1244 || !total.Day.IsZero() {
+		t.Errorf("unexpected summary %#v", total)
+	}
+}
+
+func TestReportDecoderTSVWithoutHeaders(t *testing.T) {
+	report := "123\t2019-01-01\t5\n124\t2019-01-01\t7\n"
+	dec, err := NewReportDecoder(strings.NewReader(report), DownloadFormatTSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec.Columns = []string{"CampaignId", "Date", "Impressions"}
+	rows := testReportRows(t, dec)
+	if len(rows) != 2 || rows[1].CampaignId != 124 || rows[1].Impressions != 7 {
+		t.Errorf("unexpected rows %#v", rows)
+	}
+	if err := dec.Summary(&testReportRow{}); err != ErrNoReportSummary {
+		t.Errorf("expected no summary, got %v", err)
+	}
+}
+
+func TestReportDecoderGzippedXML(t *testing.T) {
+	report := `<?xml version='1.0' encoding='UTF-8' standalone='yes'?>
+<report>
+  <report-name name="CAMPAIGN_PERFORMANCE_REPORT"/>
+  <date-range date="Jan 1, 2019-Jan 1, 2019"/>
+  <table>
+    <columns>
+      <column name="campaignID" display="Campaign ID"/>
+      <column name="day" display="Day"/>
+      <column name="cost" display="Cost"/>
+      <column name="ctr" display="CTR"/>
+    </columns>
+    <row campaignID="123" day="2019-01-01" cost="2500000" ctr="1.50%"/>
+    <row campaignID="124" day="2019-01-01" cost="0" ctr="0.00%"/>
+  </table>
+</report>`
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write([]byte(report))
+	gz.Close()
+
+	dec, err := NewReportDecoder(&buf, DownloadFormatXMLGzipped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := testReportRows(t, dec)
+	want := []testReportRow{
+		{CampaignId: 123, Day: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), Cost: 2.5, Ctr: 0.015},
+		{CampaignId: 124, Day: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("unexpected rows\n got %#v\nwant %#v", rows, want)
+	}
+	if dec.Header() != "CAMPAIGN_PERFORMANCE_REPORT" {
+		t.Errorf("unexpected header %q", dec.Header())
+	}
+}
+
+func TestReportDecoderErrors(t *testing.T) {
+	if _, err := NewReportDecoder(strings.NewReader(""), DownloadFormat("PDF")); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+
+	dec, _ := NewReportDecoder(strings.NewReader("Impressions\nlots\n"), DownloadFormatCSV)
+	var row testReportRow
+	if err := dec.Decode(&row); err == nil || !strings.Contains(err.Error(), "field Impressions") {
+		t.Errorf("expected a field error, got %v", err)
+	}
+	if err := dec.Decode(row); err == nil {
+		t.Error("expected an error decoding into a non pointer")
+	}
+}
+
+func TestReportDecoderCSVForExcel(t *testing.T) {
+	report := "\"CAMPAIGN_PERFORMANCE_REPORT (Jan 1, 2019-Jan 1, 2019)\"\nCampaign ID\tDay\tCampaign state\n123\t2019-01-01\tCafé ☕\n"
+	units := utf16.Encode([]rune(report))
+	b := []byte{0xff, 0xfe}
+	for _, u := range units {
+		b = append(b, byte(u), byte(u>>8))
+	}
+
+	dec, err := NewReportDecoder(bytes.NewReader(b), DownloadFormatCSVForExcel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := testReportRows(t, dec)
+	if len(rows) != 1 || rows[0].CampaignId != 123 || rows[0].Status != "CAFÉ ☕" {
+		t.Errorf("unexpected rows %#v", rows)
+	}
+}
@@ -1,13 +1,12 @@
 package gads
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 
 	"encoding/xml"
@@ -24,6 +23,8 @@ const (
 	DownloadFormatCSVGzipped DownloadFormat = "GZIPPED_CSV"
 	// DownloadFormatTSV is when you want like csv but separated with tabs
 	DownloadFormatTSV DownloadFormat = "TSV"
+	// DownloadFormatCSVForExcel is csv meant to be opened by Microsoft Excel
+	DownloadFormatCSVForExcel DownloadFormat = "CSVFOREXCEL"
 
 	// DateRangeTypeCustom is the type used when you specify manually the range of the report
 	DateRangeTypeCustom DateRangeType = "CUSTOM_DATE"
@@ -54,7 +55,8 @@ const (
 	DateRangeTypeLastWeekSunSat DateRangeType = "LAST_WEEK_SUN_SAT"
 )
 
-// DownloadFormat is the return type of the reports that you want to fetch
+// DownloadFormat is the return type of the reports that you want to fetch,
+// by ReportDefinitionService as well as by AWQLClient
 type DownloadFormat string
 
 // DateRangeType is the date range when you want
@@ -66,7 +68,8 @@ func (d DownloadFormat) Valid() error {
 		d != DownloadFormatXML &&
 		d != DownloadFormatCSVGzipped &&
 		d != DownloadFormatXMLGzipped &&
-		d != DownloadFormatTSV {
+		d != DownloadFormatTSV &&
+		d != DownloadFormatCSVForExcel {
 		return ErrInvalidReportDownloadType
 	}
 	return nil
@@ -87,11 +90,22 @@ type ReportDefinition struct {
 	DownloadFormat         DownloadFormat `xml:"downloadFormat"`
 	IncludeZeroImpressions bool           `xml:"-"`
 	SkipHeader             bool           `xml:"-"`
+	SkipColumnHeader       bool           `xml:"-"`
 	SkipSummary            bool           `xml:"-"`
+	UseRawEnumValues       bool           `xml:"-"`
 }
 
-// ValidRequest returns an error if the report can't be used to do request to the api
+// ValidRequest returns an error if the report can't be used to do request to the api.
+// The report type must be known and the selector can't be empty, ordered
+// or paged. The columns the catalog of the report type lists must be
+// compatible, and filterable when filtered on. The columns missing from
+// the catalog are only rejected when it is Complete, call Validate to
+// reject them from a partial catalog too.
 func (r *ReportDefinition) ValidRequest() error {
+	return r.validRequest(false)
+}
+
+func (r *ReportDefinition) validRequest(checkColumns bool) error {
 
 	if r == nil {
 		return errors.New("empty report definition")
@@ -106,6 +120,13 @@ func (r *ReportDefinition) ValidRequest() error {
 	if err := r.DownloadFormat.Valid(); err != nil {
 		return err
 	}
+	rt, ok := LookupReportType(r.ReportType)
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownReportType, r.ReportType)
+	}
+	if err := r.validColumns(rt, checkColumns); err != nil {
+		return err
+	}
 
 	if r.Selector.DateRange != nil {
 		r.DateRangeType = DateRangeTypeCustom
@@ -114,6 +135,22 @@ func (r *ReportDefinition) ValidRequest() error {
 	return nil
 }
 
+// validColumns checks the selector against the catalog rt of its report
+// type, the report types without columns are only checked by name.
+func (r *ReportDefinition) validColumns(rt *ReportTypeInfo, checkColumns bool) error {
+	if len(rt.Columns) == 0 {
+		return validateReportSelector(r.Selector)
+	}
+	return rt.validate(r.Selector, rt.Complete || checkColumns)
+}
+
+// Validate is like ValidRequest but also rejects the columns of the
+// selector missing from the ones its report type lists in ReportTypes,
+// see ReportTypeInfo.Validate.
+func (r *ReportDefinition) Validate() error {
+	return r.validRequest(true)
+}
+
 // ReportDefinitionService is the service you call when you want to access reports
 type ReportDefinitionService struct {
 	Auth
@@ -126,8 +163,17 @@ func NewReportDefinitionService(auth *Auth) *ReportDefinitionService {
 }
 
 // Request launch a request to the reporting api with the definition of the wanted report
-// We return a reader because the response format depends of the ReportDefinition.DownloadFormat field
+// We return a reader because the response format depends of the ReportDefinition.DownloadFormat field,
+// NewReportDecoder reads its rows into structs. Request needs an Auth.Client
+// timeout of 10 minutes at least, DownloadToFile and DownloadTo have their
+// own timeout and retry the download.
 func (r *ReportDefinitionService) Request(def *ReportDefinition) (body io.ReadCloser, err error) {
+	return r.RequestContext(context.Background(), def)
+}
+
+// RequestContext is like Request but binds the request to ctx. The context
+// also covers the reading of the returned body.
+func (r *ReportDefinitionService) RequestContext(ctx context.Context, def *ReportDefinition) (body io.ReadCloser, err error) {
 
 	var req *http.Request
 	req, err = r.createHTTPRequest(def)
@@ -135,59 +181,22 @@ func (r *ReportDefinitionService) Request(def *ReportDefinition) (body io.ReadCl
 		return
 	}
 
-	var resp *http.Response
-
 	// spec google, some reports can take up to 10 min to be downloaded
 	if r.Auth.Client.Timeout < (10 * time.Minute) {
 		return nil, errors.New("to fetch google reports, you need to set the http client timeout to 10 minute at last")
 	}
+	return r.send(ctx, def, req, r.Auth.Client)
+}
 
-	resp, err = r.Auth.Client.Do(req)
-	if err != nil {
-		return
-	}
-
-	body = resp.Body
-	// analyze response code
-	if resp.StatusCode != http.StatusOK {
-		// no report, try to parse it
-		var buf []byte
-		buf, err = ioutil.ReadAll(body)
-		if err != nil {
-			err = fmt.Errorf(
-				"request to report expected Code 200 but received %v and unable to read the http body",
-				resp.StatusCode,
-			)
-			return
-		}
-
-		errorExtractor := struct {
-			ErrorType string `xml:"ApiError>type,omitempty"`
-			Trigger   string `xml:"ApiError>trigger,omitempty"`
-			FieldPath string `xml:"ApiError>fieldPath,omitempty"`
-		}{}
-		xml.Unmarshal(buf, &errorExtractor)
-
-		if errorExtractor.ErrorType != "" {
-			err = fmt.Errorf(
-				"request to report expected Code 200 but received %v with error type %v - %v - %v",
-				resp.StatusCode,
-				errorExtractor.ErrorType,
-				errorExtractor.Trigger,
-				errorExtractor.FieldPath,
-			)
-		} else {
-			err = fmt.Errorf(
-				"request to report expected Code 200 but received %v and unable to read the error",
-				resp.StatusCode,
-			)
-		}
-
-		return
+// send runs the report request req through the report middlewares with
+// client.
+func (r *ReportDefinitionService) send(ctx context.Context, def *ReportDefinition, req *http.Request, client *http.Client) (io.ReadCloser, error) {
+	call := &ReportCall{
+		CustomerId: req.Header.Get("clientCustomerId"),
+		ReportType: def.ReportType,
+		Format:     string(def.DownloadFormat),
 	}
-
-	return
-
+	return r.Auth.sendReport(ctx, call, req, client)
 }
 
 // createHTTPRequest generates the http request matching the report definition
@@ -213,26 +222,11 @@ func (r *ReportDefinitionService) createHTTPRequest(def *ReportDefinition) (req
 		return nil, err
 	}
 
-	var f = url.Values{}
-	f.Set("__rdxml", string(b))
-
-	req, err = http.NewRequest("POST", reportAPIURL, strings.NewReader(f.Encode()))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("clientCustomerId", cID)
-	req.Header.Add("developerToken", r.Auth.DeveloperToken)
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	if def.SkipHeader {
-		req.Header.Add("skipReportHeader", "true")
-	}
-	if def.SkipSummary {
-		req.Header.Add("skipReportSummary", "true")
-	}
-
-	if def.IncludeZeroImpressions {
-		req.Header.Add("includeZeroImpressions", "true")
-	}
-	return
+	return r.Auth.newReportRequest(cID, url.Values{"__rdxml": {string(b)}}, reportHeaders{
+		SkipReportHeader:       def.SkipHeader,
+		SkipColumnHeader:       def.SkipColumnHeader,
+		SkipReportSummary:      def.SkipSummary,
+		IncludeZeroImpressions: def.IncludeZeroImpressions,
+		UseRawEnumValues:       def.UseRawEnumValues,
+	})
 }
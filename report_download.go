@@ -0,0 +1,205 @@
+package gads
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const defaultReportDownloadTimeout = 10 * time.Minute
+
+// ReportDownloadOptions tunes ReportDefinitionService.DownloadToFile and
+// DownloadTo. The zero value is usable.
+type ReportDownloadOptions struct {
+	// Timeout bounds every attempt, request and body reading included,
+	// defaults to 10 minutes. It replaces Auth.Client.Timeout, which is
+	// ignored, so the client can keep a short timeout for soap calls.
+	Timeout time.Duration
+	// Retry describes how failed attempts are retried, it defaults to
+	// Auth.Retry or to a default RetryPolicy when that one is nil too.
+	// Set MaxAttempts to 1 to disable retries.
+	Retry *RetryPolicy
+	// KeepCompressed writes gzipped reports as is, they are decompressed
+	// by default.
+	KeepCompressed bool
+	// Progress, when set, is called with the number of bytes written so
+	// far by the current attempt, it starts over from 0 on retries.
+	Progress func(written int64)
+}
+
+func (o *ReportDownloadOptions) timeout() time.Duration {
+	if o.Timeout <= 0 {
+		return defaultReportDownloadTimeout
+	}
+	return o.Timeout
+}
+
+// ReportDownloadResult describes a completed download.
+type ReportDownloadResult struct {
+	Bytes    int64  // bytes written
+	SHA256   string // hex encoded checksum of the bytes written
+	Attempts int
+	Duration time.Duration
+}
+
+// DownloadToFile downloads the report def into the file at path.
+//
+// The report is written in a temporary file next to path, then renamed
+// once complete, so path either holds a whole report or is left as it
+// was. Failed attempts are retried from scratch.
+//
+// Example
+//
+//   res, err := gads.NewReportDefinitionService(&auth).DownloadToFile(ctx, &def, "campaigns.csv",
+//     &gads.ReportDownloadOptions{
+//       Timeout:  30 * time.Minute,
+//       Progress: func(n int64) { log.Printf("%d bytes", n) },
+//     })
+//
+func (r *ReportDefinitionService) DownloadToFile(ctx context.Context, def *ReportDefinition, path string, opts *ReportDownloadOptions) (*ReportDownloadResult, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	res, err := r.download(ctx, def, tmp, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err = tmp.Sync(); err != nil {
+		return nil, err
+	}
+	if err = tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DownloadTo downloads the report def into w. The report is buffered in
+// a temporary file until complete so a retried attempt never writes
+// twice in w.
+func (r *ReportDefinitionService) DownloadTo(ctx context.Context, def *ReportDefinition, w io.Writer, opts *ReportDownloadOptions) (*ReportDownloadResult, error) {
+	tmp, err := os.CreateTemp("", "gads-report-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	res, err := r.download(ctx, def, tmp, opts)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(w, tmp); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// download writes the report in f, truncated before every attempt.
+func (r *ReportDefinitionService) download(ctx context.Context, def *ReportDefinition, f *os.File, opts *ReportDownloadOptions) (*ReportDownloadResult, error) {
+	if opts == nil {
+		opts = &ReportDownloadOptions{}
+	}
+	retry := opts.Retry
+	if retry == nil {
+		retry = r.Auth.Retry
+	}
+	if retry == nil {
+		retry = &RetryPolicy{}
+	}
+
+	// the timeout is enforced by the context of every attempt
+	client := http.DefaultClient
+	if r.Auth.Client != nil {
+		c := *r.Auth.Client
+		c.Timeout = 0
+		client = &c
+	}
+
+	res := &ReportDownloadResult{}
+	start := time.Now()
+	for {
+		res.Attempts++
+		var err error
+		res.Bytes, res.SHA256, err = r.downloadOnce(ctx, def, client, f, opts)
+		if err == nil {
+			res.Duration = time.Since(start)
+			return res, nil
+		}
+		if res.Attempts >= retry.maxAttempts() || ctx.Err() != nil || !isTransientReportError(err) {
+			return nil, err
+		}
+		if werr := retry.wait(ctx, res.Attempts, err); werr != nil {
+			return nil, errors.Join(err, werr)
+		}
+	}
+}
+
+func (r *ReportDefinitionService) downloadOnce(ctx context.Context, def *ReportDefinition, client *http.Client, f *os.File, opts *ReportDownloadOptions) (int64, string, error) {
+	if err := f.Truncate(0); err != nil {
+		return 0, "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return 0, "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout())
+	defer cancel()
+
+	req, err := r.createHTTPRequest(def)
+	if err != nil {
+		return 0, "", err
+	}
+	body, err := r.send(ctx, def, req, client)
+	if err != nil {
+		return 0, "", err
+	}
+	defer body.Close()
+
+	var src io.Reader = body
+	if !opts.KeepCompressed {
+		if src, err = gunzipReport(body); err != nil {
+			return 0, "", err
+		}
+	}
+	sum := sha256.New()
+	pw := &progressWriter{w: f, sum: sum, progress: opts.Progress}
+	if _, err = io.Copy(pw, src); err != nil {
+		return 0, "", err
+	}
+	return pw.written, hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// progressWriter counts and checksums the bytes written to w.
+type progressWriter struct {
+	w        io.Writer
+	sum      hash.Hash
+	written  int64
+	progress func(int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.sum.Write(b[:n])
+	p.written += int64(n)
+	if p.progress != nil && n > 0 {
+		p.progress(p.written)
+	}
+	return n, err
+}
@@ -0,0 +1,104 @@
+package gads
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testDownloadReport = "Campaign ID,Impressions\n1,10\n2,20\n"
+
+func TestReportDownloadToFile(t *testing.T) {
+	calls := 0
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(testDownloadReport))
+		gz.Close()
+	})
+	// the client timeout is too short for Request but ignored by downloads
+	auth.Client.Timeout = time.Second
+
+	path := filepath.Join(t.TempDir(), "campaigns.csv")
+	os.WriteFile(path, []byte("previous"), 0644)
+
+	var progress []int64
+	def := testAccountReportDefinition
+	def.DownloadFormat = DownloadFormatCSVGzipped
+	res, err := NewReportDefinitionService(&auth).DownloadToFile(context.Background(), &def, path, &ReportDownloadOptions{
+		Retry:    &RetryPolicy{InitialBackoff: time.Millisecond},
+		Progress: func(n int64) { progress = append(progress, n) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := os.ReadFile(path)
+	if string(b) != testDownloadReport {
+		t.Errorf("unexpected report %q", b)
+	}
+	sum := sha256.Sum256([]byte(testDownloadReport))
+	if res.Attempts != 2 || res.Bytes != int64(len(testDownloadReport)) || res.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if len(progress) == 0 || progress[len(progress)-1] != res.Bytes {
+		t.Errorf("unexpected progress %v", progress)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("expected the temporary file to be gone, got %d files", len(entries))
+	}
+}
+
+func TestReportDownloadFailure(t *testing.T) {
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`<reportDownloadError><ApiError><type>ReportDefinitionError.INVALID_FIELD_NAME_FOR_REPORT</type></ApiError></reportDownloadError>`))
+	})
+
+	path := filepath.Join(t.TempDir(), "campaigns.csv")
+	os.WriteFile(path, []byte("previous"), 0644)
+	def := testAccountReportDefinition
+	_, err := NewReportDefinitionService(&auth).DownloadToFile(context.Background(), &def, path, nil)
+	if err == nil || !strings.Contains(err.Error(), "INVALID_FIELD_NAME_FOR_REPORT") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if b, _ := os.ReadFile(path); string(b) != "previous" {
+		t.Errorf("expected the previous file to be kept, got %q", b)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("expected the temporary file to be gone, got %d files", len(entries))
+	}
+}
+
+func TestReportDownloadTimeout(t *testing.T) {
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Campaign ID\n"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	var buf bytes.Buffer
+	def := testAccountReportDefinition
+	_, err := NewReportDefinitionService(&auth).DownloadTo(context.Background(), &def, &buf, &ReportDownloadOptions{
+		Timeout: 50 * time.Millisecond,
+		Retry:   &RetryPolicy{MaxAttempts: 1},
+	})
+	if err == nil {
+		t.Fatal("expected the download to time out")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing written on failure, got %q", buf.String())
+	}
+}
@@ -0,0 +1,15 @@
+// Package reportexport converts gads reports to Parquet and JSON lines.
+//
+// The rows are read by a gads.ReportDecoder, so any report downloaded by
+// ReportDefinitionService or AWQLClient can be exported, whatever its
+// download format:
+//
+//   body, err := gads.NewAWQLClient(&auth).Download(gads.AWQLRequest{
+//     Query:  "SELECT CampaignId, Date, Cost FROM CAMPAIGN_PERFORMANCE_REPORT DURING YESTERDAY",
+//     Format: gads.DownloadFormatCSVGzipped,
+//   })
+//   dec, err := gads.NewReportDecoder(body, gads.DownloadFormatCSVGzipped)
+//   rows, err := reportexport.WriteParquet(f, dec, nil)
+//
+// The column types come from the report type catalog, gads.ReportTypes.
+package reportexport
@@ -0,0 +1,57 @@
+package reportexport
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/querian/gads"
+)
+
+// WriteJSONL writes the rows of dec in w as JSON lines, one object per row
+// with a member per column in the report order. Members are named after
+// the api field names when the report type is in gads.ReportTypes, after
+// the report header otherwise. Money columns stay in micros, dates are
+// written as "2006-01-02" strings and nulls as null.
+//
+// It returns the number of rows written.
+func WriteJSONL(w io.Writer, dec *gads.ReportDecoder) (rows int64, err error) {
+	bw := bufio.NewWriter(w)
+	var keys [][]byte
+	for {
+		values, err := dec.DecodeValues()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rows, err
+		}
+		if keys == nil {
+			for _, c := range dec.ReportColumns() {
+				key, _ := json.Marshal(c.Name)
+				keys = append(keys, key)
+			}
+		}
+
+		bw.WriteByte('{')
+		for i, v := range values {
+			if i > 0 {
+				bw.WriteByte(',')
+			}
+			bw.Write(keys[i])
+			bw.WriteByte(':')
+			if t, ok := v.(time.Time); ok {
+				v = t.Format("2006-01-02")
+			}
+			b, err := json.Marshal(v)
+			if err != nil {
+				return rows, err
+			}
+			bw.Write(b)
+		}
+		bw.WriteString("}\n")
+		rows++
+	}
+	return rows, bw.Flush()
+}
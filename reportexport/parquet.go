@@ -0,0 +1,147 @@
+package reportexport
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/querian/gads"
+	"github.com/xitongsys/parquet-go/marshal"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+const defaultRowGroupSize = 100000
+
+// ParquetOptions tunes WriteParquet. The zero value is usable.
+type ParquetOptions struct {
+	// RowGroupSize is the number of rows buffered in memory before they
+	// are written as a row group, defaults to 100,000.
+	RowGroupSize int
+}
+
+// WriteParquet writes the rows of dec in w as a Parquet file. The schema
+// is derived from the column types of the report type in
+// gads.ReportTypes:
+//
+//   Long, Money   INT64, money in micros
+//   Integer       INT32
+//   Double        DOUBLE, percentages as fractions
+//   Date          INT32 annotated DATE
+//   others        BYTE_ARRAY annotated UTF8, enums included
+//
+// Every column is optional, the null placeholders of the report being
+// written as nulls. Pages are Snappy compressed.
+//
+// It returns the number of rows written.
+func WriteParquet(w io.Writer, dec *gads.ReportDecoder, opts *ParquetOptions) (rows int64, err error) {
+	groupSize := int64(defaultRowGroupSize)
+	if opts != nil && opts.RowGroupSize > 0 {
+		groupSize = int64(opts.RowGroupSize)
+	}
+
+	var pw *writer.ParquetWriter
+	var columns []*parquet.SchemaElement
+	for {
+		values, err := dec.DecodeValues()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rows, err
+		}
+		if pw == nil {
+			columns = parquetSchema(dec.ReportColumns())
+			if pw, err = newParquetWriter(w, columns); err != nil {
+				return rows, err
+			}
+		}
+		if err := pw.Write(parquetRow(columns[1:], values)); err != nil {
+			return rows, err
+		}
+		rows++
+		if rows%groupSize == 0 {
+			if err := pw.Flush(true); err != nil {
+				return rows, err
+			}
+		}
+	}
+	if pw == nil {
+		// a report without rows still has its columns
+		if pw, err = newParquetWriter(w, parquetSchema(dec.ReportColumns())); err != nil {
+			return rows, err
+		}
+	}
+	return rows, pw.WriteStop()
+}
+
+// newParquetWriter starts a Parquet file of schema in w, the rows are
+// written as slices of values in the schema order.
+func newParquetWriter(w io.Writer, schema []*parquet.SchemaElement) (*writer.ParquetWriter, error) {
+	pw, err := writer.NewParquetWriterFromWriter(w, schema, 1)
+	if err != nil {
+		return nil, err
+	}
+	pw.MarshalFunc = marshal.MarshalCSV
+	return pw, nil
+}
+
+// parquetSchema returns the schema elements of columns, preceded by the
+// root element.
+func parquetSchema(columns []gads.ReportColumn) []*parquet.SchemaElement {
+	root := parquet.NewSchemaElement()
+	root.Name = "schema"
+	root.NumChildren = parquetI32(int32(len(columns)))
+	schema := []*parquet.SchemaElement{root}
+	for _, c := range columns {
+		e := parquet.NewSchemaElement()
+		e.Name = c.Name
+		e.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_OPTIONAL)
+		typ, converted := parquet.Type_BYTE_ARRAY, parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8)
+		switch c.Type {
+		case "Long", "Money":
+			typ, converted = parquet.Type_INT64, nil
+		case "Integer":
+			typ, converted = parquet.Type_INT32, nil
+		case "Double":
+			typ, converted = parquet.Type_DOUBLE, nil
+		case "Date":
+			typ, converted = parquet.Type_INT32, parquet.ConvertedTypePtr(parquet.ConvertedType_DATE)
+		}
+		e.Type, e.ConvertedType = parquet.TypePtr(typ), converted
+		schema = append(schema, e)
+	}
+	return schema
+}
+
+func parquetI32(n int32) *int32 {
+	return &n
+}
+
+// parquetRow converts the decoded values of a row to the Go types of the
+// physical types of columns.
+func parquetRow(columns []*parquet.SchemaElement, values []interface{}) []interface{} {
+	row := make([]interface{}, len(columns))
+	for i, c := range columns {
+		if i >= len(values) || values[i] == nil {
+			continue
+		}
+		v := values[i]
+		switch c.GetType() {
+		case parquet.Type_INT64:
+			row[i] = v.(int64)
+		case parquet.Type_INT32:
+			if t, ok := v.(time.Time); ok {
+				// days since the epoch, the dates of reports have no time zone
+				row[i] = int32(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400)
+			} else {
+				row[i] = int32(v.(int64))
+			}
+		case parquet.Type_DOUBLE:
+			row[i] = v.(float64)
+		default:
+			row[i] = fmt.Sprint(v)
+		}
+	}
+	return row
+}
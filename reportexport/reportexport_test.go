@@ -0,0 +1,113 @@
+package reportexport
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/querian/gads"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+const testReport = `"CAMPAIGN_PERFORMANCE_REPORT (Jan 1, 2019-Jan 2, 2019)"
+Campaign ID,Day,Campaign state,Impressions,Cost,CTR,Labels
+123,2019-01-01,enabled,"1,234",1500000,12.50%,brand
+124,2019-01-02,paused,0,0,--, --
+Total,--,--,"1,234",1500000,12.50%,--
+`
+
+func testDecoder(t *testing.T) *gads.ReportDecoder {
+	dec, err := gads.NewReportDecoder(strings.NewReader(testReport), gads.DownloadFormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dec
+}
+
+func TestWriteJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	rows, err := WriteJSONL(&buf, testDecoder(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"CampaignId":123,"Date":"2019-01-01","CampaignStatus":"enabled","Impressions":1234,"Cost":1500000,"Ctr":0.125,"Labels":"brand"}
+{"CampaignId":124,"Date":"2019-01-02","CampaignStatus":"paused","Impressions":0,"Cost":0,"Ctr":null,"Labels":null}
+`
+	if rows != 2 || buf.String() != want {
+		t.Errorf("unexpected %d rows\n got %s\nwant %s", rows, buf.String(), want)
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	var buf bytes.Buffer
+	rows, err := WriteParquet(&buf, testDecoder(t), &ParquetOptions{RowGroupSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pr, err := reader.NewParquetReader(buffer.NewBufferFileFromBytes(buf.Bytes()), nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.ReadStop()
+	if rows != 2 || pr.GetNumRows() != 2 {
+		t.Errorf("expected 2 rows, got %d and %d", rows, pr.GetNumRows())
+	}
+	if len(pr.Footer.RowGroups) != 2 {
+		t.Errorf("expected a row group per row, got %d", len(pr.Footer.RowGroups))
+	}
+
+	var names []string
+	types := map[string]string{}
+	for _, e := range pr.Footer.Schema[1:] {
+		names = append(names, e.Name)
+		types[e.Name] = e.GetType().String()
+		if e.IsSetConvertedType() {
+			types[e.Name] += " " + e.GetConvertedType().String()
+		}
+	}
+	if strings.Join(names, ",") != "CampaignId,Date,CampaignStatus,Impressions,Cost,Ctr,Labels" {
+		t.Errorf("unexpected schema %v", names)
+	}
+	if types["Date"] != "INT32 DATE" || types["Cost"] != "INT64" || types["Ctr"] != "DOUBLE" ||
+		types["CampaignStatus"] != "BYTE_ARRAY UTF8" || types["Labels"] != "BYTE_ARRAY UTF8" {
+		t.Errorf("unexpected column types %v", types)
+	}
+
+	want := [][]interface{}{
+		{int64(123), int64(124)},
+		{int32(17897), int32(17898)},
+		{"enabled", "paused"},
+		{int64(1234), int64(0)},
+		{int64(1500000), int64(0)},
+		{0.125, nil},
+		{"brand", nil},
+	}
+	for i, w := range want {
+		values, _, _, err := pr.ReadColumnByIndex(int64(i), 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(values) != fmt.Sprint(w) {
+			t.Errorf("unexpected %s values %v, want %v", names[i], values, w)
+		}
+	}
+}
+
+func TestWriteParquetEmpty(t *testing.T) {
+	dec, _ := gads.NewReportDecoder(strings.NewReader("Campaign ID,Impressions\n"), gads.DownloadFormatCSV)
+	var buf bytes.Buffer
+	rows, err := WriteParquet(&buf, dec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pr, err := reader.NewParquetReader(buffer.NewBufferFileFromBytes(buf.Bytes()), nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.ReadStop()
+	if rows != 0 || pr.GetNumRows() != 0 || len(pr.Footer.Schema) != 3 {
+		t.Errorf("unexpected %d rows, schema %v", rows, pr.Footer.Schema)
+	}
+}
@@ -1,7 +1,43 @@
 package gads
 
+import "context"
+
 // BaseResponse is the common structure of every response received from
 // Google Adwords in the most common case
 type BaseResponse struct {
 	PartialFailureErrors PartialFailureErrors `xml:"rval>partialFailureErrors,omitempty"`
 }
+
+// ResponseHeader is the metadata sent by the api along every soap
+// response. RequestId is needed when contacting Google support and
+// Operations is the number of operations charged to the quota.
+type ResponseHeader struct {
+	RequestId    string `xml:"requestId"`
+	ServiceName  string `xml:"serviceName"`
+	MethodName   string `xml:"methodName"`
+	Operations   int64  `xml:"operations"`
+	ResponseTime int64  `xml:"responseTime"` // in milliseconds
+}
+
+type responseHeaderKey struct{}
+
+// WithResponseHeader returns a context that makes the service methods
+// called with it store the header of their response in h.
+//
+//   var h gads.ResponseHeader
+//   campaigns, totalCount, err := cs.GetContext(gads.WithResponseHeader(ctx, &h), selector)
+//   log.Printf("request %s cost %d operations", h.RequestId, h.Operations)
+func WithResponseHeader(ctx context.Context, h *ResponseHeader) context.Context {
+	return context.WithValue(ctx, responseHeaderKey{}, h)
+}
+
+// handleResponseHeader hands the header of a response to whoever asked
+// for it.
+func (a *Auth) handleResponseHeader(ctx context.Context, h ResponseHeader) {
+	if out, ok := ctx.Value(responseHeaderKey{}).(*ResponseHeader); ok && out != nil {
+		*out = h
+	}
+	if a.OnResponseHeader != nil {
+		a.OnResponseHeader(h)
+	}
+}
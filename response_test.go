@@ -0,0 +1,55 @@
+package gads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestResponseHeader(t *testing.T) {
+	fail := false
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, testRateExceededFault)
+			return
+		}
+		fmt.Fprint(w, testEmptyGetResponse)
+	})
+	var operations int64
+	auth.OnResponseHeader = func(h ResponseHeader) {
+		operations += h.Operations
+	}
+	cs := NewCampaignService(&auth)
+
+	var h ResponseHeader
+	_, _, err := cs.GetContext(WithResponseHeader(context.Background(), &h), Selector{Fields: []string{"Id"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ResponseHeader{
+		RequestId:    "0005a1b2c3d5",
+		ServiceName:  "CampaignService",
+		MethodName:   "get",
+		Operations:   1,
+		ResponseTime: 42,
+	}
+	if h != expected {
+		t.Errorf("expected header %#v, got %#v", expected, h)
+	}
+	if operations != 1 {
+		t.Errorf("expected the hook to count 1 operation, got %d", operations)
+	}
+
+	fail = true
+	_, _, err = cs.Get(Selector{Fields: []string{"Id"}})
+	var faults *ErrorsType
+	if !errors.As(err, &faults) {
+		t.Fatalf("expected an ErrorsType, got %#v", err)
+	}
+	if faults.Header.RequestId != "0005a1b2c3d4" {
+		t.Errorf("expected the request id on the error, got %#v", faults.Header)
+	}
+}
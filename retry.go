@@ -0,0 +1,166 @@
+package gads
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts    = 5
+	defaultRetryInitialBackoff = time.Second
+	defaultRetryMaxBackoff     = time.Minute
+
+	defaultPollInterval    = 15 * time.Second
+	defaultMaxPollInterval = 5 * time.Minute
+)
+
+// RetryPolicy describes how transient failures of api calls are retried.
+// Retrying is opt-in, set Auth.Retry and every service created from that
+// Auth will use it.
+//
+// Rate exceeded errors wait at least the retryAfterSeconds sent by the api.
+// Internal api errors, 5xx responses without a soap body and connection
+// resets wait for a jittered exponential backoff.
+//
+// Note that a mutate interrupted by a connection reset may have been
+// applied by the api before being retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first
+	// one, defaults to 5.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, it doubles on
+	// every following attempt. Defaults to 1 second.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponential backoff, defaults to 1 minute.
+	MaxBackoff time.Duration
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts <= 0 {
+		return defaultRetryMaxAttempts
+	}
+	return p.MaxAttempts
+}
+
+// shouldRetry reports whether the failed attempt can be retried.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, attempt int, err error) bool {
+	if p == nil || attempt >= p.maxAttempts() || ctx.Err() != nil {
+		return false
+	}
+	return isTransientError(err)
+}
+
+// backoff returns the jittered delay to wait after the given attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial, max := p.InitialBackoff, p.MaxBackoff
+	if initial <= 0 {
+		initial = defaultRetryInitialBackoff
+	}
+	if max <= 0 {
+		max = defaultRetryMaxBackoff
+	}
+	d := initial
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	// equal jitter, between half and the whole delay
+	half := int64(d / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// wait sleeps before the next attempt, it returns early with the context
+// error if ctx is done.
+func (p *RetryPolicy) wait(ctx context.Context, attempt int, err error) error {
+	d := p.backoff(attempt)
+	if ra := retryAfter(err); ra > d {
+		d = ra
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retryAfter returns the longest delay requested by the rate exceeded
+// errors contained in err.
+func retryAfter(err error) (d time.Duration) {
+	var faults *ErrorsType
+	if !errors.As(err, &faults) {
+		return 0
+	}
+	for _, e := range faults.ApiErrors() {
+		if re, ok := e.(RateExceededError); ok {
+			if ra := time.Duration(re.RetryAfterSeconds) * time.Second; ra > d {
+				d = ra
+			}
+		}
+	}
+	return d
+}
+
+// isTransientError reports whether err is worth retrying as is.
+func isTransientError(err error) bool {
+	var faults *ErrorsType
+	if errors.As(err, &faults) {
+		for _, e := range faults.ApiErrors() {
+			switch e.(type) {
+			case RateExceededError, InternalApiError:
+				return true
+			}
+		}
+		return false
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// Polling tunes how the Wait methods poll an asynchronous operation, like
+// a batch job or the creation of a trial, the interval doubling after
+// every poll. The zero value is usable.
+type Polling struct {
+	// Interval is the delay before the first poll, defaults to 15
+	// seconds.
+	Interval time.Duration
+	// MaxInterval caps the delay between polls, defaults to 5 minutes.
+	MaxInterval time.Duration
+}
+
+// wait sleeps before the attempt-th poll, a nil Polling uses the defaults.
+func (p *Polling) wait(ctx context.Context, attempt int) error {
+	backoff := &RetryPolicy{
+		InitialBackoff: defaultPollInterval,
+		MaxBackoff:     defaultMaxPollInterval,
+	}
+	if p != nil && p.Interval > 0 {
+		backoff.InitialBackoff = p.Interval
+	}
+	if p != nil && p.MaxInterval > 0 {
+		backoff.MaxBackoff = p.MaxInterval
+	}
+	t := time.NewTimer(backoff.backoff(attempt))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,165 @@
+package gads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testRateExceededFault = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Header>
+    <ResponseHeader xmlns="https://adwords.google.com/api/adwords/cm/v201809">
+      <requestId>0005a1b2c3d4</requestId>
+    </ResponseHeader>
+  </soap:Header>
+  <soap:Body>
+    <soap:Fault>
+      <faultcode>soap:Server</faultcode>
+      <faultstring>[RateExceededError &lt;rateName=RATE_LIMIT, rateKey=null, rateScope=ACCOUNT, retryAfterSeconds=0&gt;]</faultstring>
+      <detail>
+        <ApiExceptionFault xmlns="https://adwords.google.com/api/adwords/cm/v201809">
+          <message>[RateExceededError &lt;rateName=RATE_LIMIT, rateKey=null, rateScope=ACCOUNT, retryAfterSeconds=0&gt;]</message>
+          <ApplicationException.Type>ApiException</ApplicationException.Type>
+          <errors xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="RateExceededError">
+            <fieldPath></fieldPath>
+            <trigger></trigger>
+            <errorString>RateExceededError.RATE_EXCEEDED</errorString>
+            <ApiError.Type>RateExceededError</ApiError.Type>
+            <reason>RATE_EXCEEDED</reason>
+            <rateName>RATE_LIMIT</rateName>
+            <rateScope>ACCOUNT</rateScope>
+            <retryAfterSeconds>0</retryAfterSeconds>
+          </errors>
+        </ApiExceptionFault>
+      </detail>
+    </soap:Fault>
+  </soap:Body>
+</soap:Envelope>`
+
+const testEmptyGetResponse = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Header>
+    <ResponseHeader xmlns="https://adwords.google.com/api/adwords/cm/v201809">
+      <requestId>0005a1b2c3d5</requestId>
+      <serviceName>CampaignService</serviceName>
+      <methodName>get</methodName>
+      <operations>1</operations>
+      <responseTime>42</responseTime>
+    </ResponseHeader>
+  </soap:Header>
+  <soap:Body>
+    <getResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809">
+      <rval>
+        <totalNumEntries>0</totalNumEntries>
+      </rval>
+    </getResponse>
+  </soap:Body>
+</soap:Envelope>`
+
+// testStubAuth returns an Auth whose requests are all sent to handler.
+func testStubAuth(t *testing.T, handler http.HandlerFunc) Auth {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := server.Client()
+	client.Transport = rewriteTransport{base: client.Transport, url: server.URL}
+	return Auth{
+		CustomerId:     "123-456-7890",
+		DeveloperToken: "dev-token",
+		UserAgent:      "gads-test",
+		Client:         client,
+	}
+}
+
+// rewriteTransport sends every request to the test server.
+type rewriteTransport struct {
+	base http.RoundTripper
+	url  string
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Scheme = "http"
+	r.URL.Host = t.url[len("http://"):]
+	return t.base.RoundTrip(r)
+}
+
+func TestRetryRateExceeded(t *testing.T) {
+	calls := 0
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, testRateExceededFault)
+			return
+		}
+		fmt.Fprint(w, testEmptyGetResponse)
+	})
+	auth.Retry = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	_, _, err := NewCampaignService(&auth).Get(Selector{Fields: []string{"Id"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryBudgetExhausted(t *testing.T) {
+	calls := 0
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "<html>bad gateway</html>")
+	})
+	auth.Retry = &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}
+
+	_, _, err := NewCampaignService(&auth).Get(Selector{Fields: []string{"Id"}})
+	if _, ok := err.(*HTTPStatusError); !ok {
+		t.Fatalf("expected an HTTPStatusError, got %#v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestRetryDisabled(t *testing.T) {
+	calls := 0
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, testRateExceededFault)
+	})
+
+	_, _, err := NewCampaignService(&auth).Get(Selector{Fields: []string{"Id"}})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	auth.Retry = &RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, err := NewCampaignService(&auth).GetContext(ctx, Selector{Fields: []string{"Id"}})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("retry did not stop with the context")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the context error, got %v", err)
+	}
+}
@@ -0,0 +1,245 @@
+package gads
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Operator is the operator of a selector predicate.
+type Operator string
+
+// Predicate operators
+const (
+	OperatorEquals                   Operator = "EQUALS"
+	OperatorNotEquals                Operator = "NOT_EQUALS"
+	OperatorIn                       Operator = "IN"
+	OperatorNotIn                    Operator = "NOT_IN"
+	OperatorGreaterThan              Operator = "GREATER_THAN"
+	OperatorGreaterThanEquals        Operator = "GREATER_THAN_EQUALS"
+	OperatorLessThan                 Operator = "LESS_THAN"
+	OperatorLessThanEquals           Operator = "LESS_THAN_EQUALS"
+	OperatorStartsWith               Operator = "STARTS_WITH"
+	OperatorStartsWithIgnoreCase     Operator = "STARTS_WITH_IGNORE_CASE"
+	OperatorContains                 Operator = "CONTAINS"
+	OperatorContainsIgnoreCase       Operator = "CONTAINS_IGNORE_CASE"
+	OperatorDoesNotContain           Operator = "DOES_NOT_CONTAIN"
+	OperatorDoesNotContainIgnoreCase Operator = "DOES_NOT_CONTAIN_IGNORE_CASE"
+	OperatorContainsAny              Operator = "CONTAINS_ANY"
+	OperatorContainsAll              Operator = "CONTAINS_ALL"
+	OperatorContainsNone             Operator = "CONTAINS_NONE"
+)
+
+// multiValued reports whether op accepts several values, the other
+// operators take exactly one.
+func (op Operator) multiValued() bool {
+	switch op {
+	case OperatorIn, OperatorNotIn, OperatorContainsAny, OperatorContainsAll, OperatorContainsNone:
+		return true
+	}
+	return false
+}
+
+func (op Operator) valid() bool {
+	switch op {
+	case OperatorEquals, OperatorNotEquals, OperatorIn, OperatorNotIn,
+		OperatorGreaterThan, OperatorGreaterThanEquals, OperatorLessThan, OperatorLessThanEquals,
+		OperatorStartsWith, OperatorStartsWithIgnoreCase, OperatorContains, OperatorContainsIgnoreCase,
+		OperatorDoesNotContain, OperatorDoesNotContainIgnoreCase,
+		OperatorContainsAny, OperatorContainsAll, OperatorContainsNone:
+		return true
+	}
+	return false
+}
+
+// validatePredicate checks the operator of p and its number of values.
+func validatePredicate(p Predicate) error {
+	op := Operator(p.Operator)
+	switch {
+	case !op.valid():
+		return fmt.Errorf("gads: unknown operator %q on %q", p.Operator, p.Field)
+	case len(p.Values) == 0:
+		return fmt.Errorf("gads: %s on %q without values", op, p.Field)
+	case len(p.Values) > 1 && !op.multiValued():
+		return fmt.Errorf("gads: %s on %q takes a single value, got %d", op, p.Field, len(p.Values))
+	}
+	return nil
+}
+
+// SortOrder is the order of a selector ordering.
+type SortOrder string
+
+// Sort orders
+const (
+	SortOrderAscending  SortOrder = "ASCENDING"
+	SortOrderDescending SortOrder = "DESCENDING"
+)
+
+// maxPageSize is the largest numberResults accepted by the api.
+const maxPageSize = 10000
+
+// FieldCatalog lists the fields a service accepts in its selectors.
+type FieldCatalog struct {
+	Service    string
+	Selectable []string
+	Filterable []string
+	// Sortable defaults to the selectable fields when nil.
+	Sortable []string
+	// Complete is set when the catalog lists all the documented fields of
+	// the service, its unknown fields are then rejected. The fields
+	// missing from an incomplete catalog are only reported by Unknown.
+	Complete bool
+}
+
+func (c *FieldCatalog) sortable() []string {
+	if c.Sortable == nil {
+		return c.Selectable
+	}
+	return c.Sortable
+}
+
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Unknown returns the fields of selector missing from the catalog, joined
+// in the error.
+func (c *FieldCatalog) Unknown(selector Selector) error {
+	if c == nil {
+		return nil
+	}
+	var errs []error
+	for _, f := range selector.Fields {
+		if !containsField(c.Selectable, f) {
+			errs = append(errs, fmt.Errorf("gads: %q is not a selectable field of %s", f, c.Service))
+		}
+	}
+	for _, p := range selector.Predicates {
+		if !containsField(c.Filterable, p.Field) {
+			errs = append(errs, fmt.Errorf("gads: %q is not a filterable field of %s", p.Field, c.Service))
+		}
+	}
+	for _, o := range selector.Ordering {
+		if !containsField(c.sortable(), o.Field) {
+			errs = append(errs, fmt.Errorf("gads: %q is not a sortable field of %s", o.Field, c.Service))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the fields, predicates, ordering and paging of selector
+// against the catalog. All the problems found are joined in the error. The
+// unknown fields are only rejected by a Complete catalog.
+func (c *FieldCatalog) Validate(selector Selector) error {
+	var errs []error
+	if c != nil && c.Complete {
+		errs = append(errs, c.Unknown(selector))
+	}
+	if len(selector.Fields) == 0 {
+		errs = append(errs, errors.New("gads: selector without fields"))
+	}
+	for _, p := range selector.Predicates {
+		errs = append(errs, validatePredicate(p))
+	}
+	for _, o := range selector.Ordering {
+		if so := SortOrder(o.SortOrder); so != SortOrderAscending && so != SortOrderDescending {
+			errs = append(errs, fmt.Errorf("gads: unknown sort order %q on %q", o.SortOrder, o.Field))
+		}
+	}
+	if p := selector.Paging; p != nil {
+		if p.Offset < 0 {
+			errs = append(errs, fmt.Errorf("gads: negative paging offset %d", p.Offset))
+		}
+		if p.Limit <= 0 || p.Limit > maxPageSize {
+			errs = append(errs, fmt.Errorf("gads: paging limit %d out of 1..%d", p.Limit, maxPageSize))
+		}
+	}
+	if r := selector.DateRange; r != nil && time.Time(r.Max).Before(time.Time(r.Min)) {
+		errs = append(errs, errors.New("gads: date range ends before it starts"))
+	}
+	return errors.Join(errs...)
+}
+
+// SelectorBuilder builds a Selector checked against the field catalog of
+// a service, so misspelled fields and invalid operators fail before any
+// request. The few catalogs which are not Complete may miss fields of the
+// api, the fields they do not list are reported by Unknown rather than
+// failing Build.
+//
+// Example
+//
+//   selector, err := gads.NewSelector(&gads.CampaignFields).
+//     Fields("Id", "Name", "Status").
+//     Where("Status", gads.OperatorIn, "ENABLED", "PAUSED").
+//     OrderBy("Name", gads.SortOrderAscending).
+//     Page(0, 500).
+//     Build()
+//   if err := gads.NewSelector(&gads.AdGroupAdFields).Fields("Id", "HeadlinePart1").Unknown(); err != nil {
+//     log.Print(err)
+//   }
+//
+type SelectorBuilder struct {
+	catalog  *FieldCatalog
+	selector Selector
+}
+
+// NewSelector starts a selector for the service described by catalog. A
+// nil catalog only checks the operators, sort orders and paging.
+func NewSelector(catalog *FieldCatalog) *SelectorBuilder {
+	return &SelectorBuilder{catalog: catalog}
+}
+
+// Fields adds fields to select.
+func (b *SelectorBuilder) Fields(fields ...string) *SelectorBuilder {
+	b.selector.Fields = append(b.selector.Fields, fields...)
+	return b
+}
+
+// Where adds a predicate, all the predicates must match.
+func (b *SelectorBuilder) Where(field string, op Operator, values ...string) *SelectorBuilder {
+	b.selector.Predicates = append(b.selector.Predicates, Predicate{
+		Field:    field,
+		Operator: string(op),
+		Values:   values,
+	})
+	return b
+}
+
+// OrderBy adds an ordering, the first one added takes precedence.
+func (b *SelectorBuilder) OrderBy(field string, order SortOrder) *SelectorBuilder {
+	b.selector.Ordering = append(b.selector.Ordering, OrderBy{
+		Field:     field,
+		SortOrder: string(order),
+	})
+	return b
+}
+
+// Page sets the paging of the selector.
+func (b *SelectorBuilder) Page(offset, limit int64) *SelectorBuilder {
+	b.selector.Paging = &Paging{Offset: offset, Limit: limit}
+	return b
+}
+
+// During sets the date range of the stats of the selector.
+func (b *SelectorBuilder) During(min, max time.Time) *SelectorBuilder {
+	b.selector.DateRange = &DateRange{Min: Date(min), Max: Date(max)}
+	return b
+}
+
+// Unknown returns the fields of the selector missing from the catalog.
+func (b *SelectorBuilder) Unknown() error {
+	return b.catalog.Unknown(b.selector)
+}
+
+// Build validates and returns the selector.
+func (b *SelectorBuilder) Build() (Selector, error) {
+	if err := b.catalog.Validate(b.selector); err != nil {
+		return Selector{}, err
+	}
+	return b.selector, nil
+}
@@ -0,0 +1,92 @@
+package gads
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelectorBuilder(t *testing.T) {
+	selector, err := NewSelector(&CampaignFields).
+		Fields("Id", "Name", "Status").
+		Where("Status", OperatorIn, "ENABLED", "PAUSED").
+		Where("Name", OperatorContainsIgnoreCase, "brand").
+		OrderBy("Name", SortOrderAscending).
+		Page(0, 500).
+		During(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selector.Fields) != 3 || len(selector.Predicates) != 2 || selector.Paging.Limit != 500 {
+		t.Errorf("unexpected selector %#v", selector)
+	}
+	if p := selector.Predicates[0]; p.Operator != "IN" || len(p.Values) != 2 {
+		t.Errorf("unexpected predicate %#v", p)
+	}
+}
+
+func TestSelectorBuilderErrors(t *testing.T) {
+	_, err := NewSelector(&CampaignFields).
+		Fields("Id", "Status").
+		Where("Status", Operator("EQUAL"), "ENABLED").
+		Where("Id", OperatorEquals, "1", "2").
+		Where("Id", OperatorIn).
+		OrderBy("Name", SortOrder("UP")).
+		Page(-1, 0).
+		Build()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{
+		`unknown operator "EQUAL"`,
+		`EQUALS on "Id" takes a single value, got 2`,
+		`IN on "Id" without values`,
+		`unknown sort order "UP" on "Name"`,
+		`negative paging offset -1`,
+		`paging limit 0 out of 1..10000`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+
+	if _, err := NewSelector(nil).Fields("Anything").Where("Any", OperatorContainsAny, "a", "b").Build(); err != nil {
+		t.Errorf("nil catalog should only check the operators: %v", err)
+	}
+	if _, err := NewSelector(&LabelFields).Build(); err == nil {
+		t.Error("expected an error for a selector without fields")
+	}
+}
+
+func TestSelectorBuilderUnknownFields(t *testing.T) {
+	_, err := NewSelector(&CampaignFields).
+		Fields("Id", "Stauts", "BaseCampaignId").
+		Where("UrlCustomParameters", OperatorEquals, "x").
+		OrderBy("Nmae", SortOrderDescending).
+		Build()
+	if err == nil {
+		t.Fatal("expected the unknown fields to be rejected")
+	}
+	for _, want := range []string{
+		`"Stauts" is not a selectable field of CampaignService`,
+		`"UrlCustomParameters" is not a filterable field of CampaignService`,
+		`"Nmae" is not a sortable field of CampaignService`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "BaseCampaignId") {
+		t.Errorf("BaseCampaignId is selectable: %v", err)
+	}
+
+	// an incomplete catalog only reports the fields it does not list
+	b := NewSelector(&AdGroupAdFields).Fields("Id", "HeadlinePart1")
+	if _, err := b.Build(); err != nil {
+		t.Errorf("an incomplete catalog should not reject unknown fields: %v", err)
+	}
+	if err := b.Unknown(); err == nil || !strings.Contains(err.Error(), `"HeadlinePart1" is not a selectable field of AdGroupAdService`) {
+		t.Errorf("expected HeadlinePart1 to be reported, got %v", err)
+	}
+}
@@ -1,5 +1,13 @@
 package gads
 
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 type SharedCriterionService struct {
 	Auth
 }
@@ -7,3 +15,370 @@ type SharedCriterionService struct {
 func NewSharedCriterionService(auth *Auth) *SharedCriterionService {
 	return &SharedCriterionService{Auth: *auth}
 }
+
+// SharedCriterion is a criterion of a shared set, the criteria of the
+// negative sets are negative.
+type SharedCriterion struct {
+	SharedSetId int64     `xml:"sharedSetId"`
+	Criterion   Criterion `xml:"criterion"`
+	Negative    bool      `xml:"negative"`
+}
+
+func (sc *SharedCriterion) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	for token, err := dec.Token(); err == nil; token, err = dec.Token() {
+		if err != nil {
+			return err
+		}
+		switch start := token.(type) {
+		case xml.StartElement:
+			tag := start.Name.Local
+			switch tag {
+			case "sharedSetId":
+				if err := dec.DecodeElement(&sc.SharedSetId, &start); err != nil {
+					return err
+				}
+			case "criterion":
+				criterion, err := criterionUnmarshalXML(dec, start)
+				if err != nil {
+					return err
+				}
+				sc.Criterion = criterion
+			case "negative":
+				if err := dec.DecodeElement(&sc.Negative, &start); err != nil {
+					return err
+				}
+			default:
+				if StrictMode {
+					return fmt.Errorf("unknown SharedCriterion field %s", tag)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SharedCriterionOperations is a map of operations to perform on SharedCriterion's
+type SharedCriterionOperations map[string][]SharedCriterion
+
+// SharedCriterionFields is the catalog of the fields accepted by the selectors of
+// SharedCriterionService.Get, see NewSelector.
+var SharedCriterionFields = FieldCatalog{
+	Service: "SharedCriterionService",
+	Selectable: []string{
+		"AppId", "CriteriaType", "DisplayName", "Id", "KeywordMatchType",
+		"KeywordText", "MobileAppCategoryId", "Negative", "PlacementUrl",
+		"SharedSetId",
+	},
+	Filterable: []string{
+		"AppId", "CriteriaType", "Id", "KeywordMatchType", "KeywordText",
+		"MobileAppCategoryId", "Negative", "PlacementUrl", "SharedSetId",
+	},
+	Complete: true,
+}
+
+// Get returns an array of SharedCriterion's and the total number of SharedCriterion's
+// matching the selector.
+//
+// Example
+//
+//   sharedCriterions, totalCount, err := sharedCriterionService.Get(
+//     Selector{
+//       Fields: []string{"SharedSetId","Id","KeywordText","KeywordMatchType"},
+//       Predicates: []Predicate{
+//         {"SharedSetId", "EQUALS", []string{"1234"}},
+//       },
+//     },
+//   )
+//
+// Selectable fields are
+//   "AppId", "CriteriaType", "DisplayName", "Id", "KeywordMatchType",
+//   "KeywordText", "MobileAppCategoryId", "Negative", "PlacementUrl",
+//   "SharedSetId"
+//
+// filterable fields are
+//   "AppId", "CriteriaType", "Id", "KeywordMatchType", "KeywordText",
+//   "MobileAppCategoryId", "Negative", "PlacementUrl", "SharedSetId"
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/SharedCriterionService#get
+//
+func (s *SharedCriterionService) Get(selector Selector) (sharedCriterions []SharedCriterion, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *SharedCriterionService) GetContext(ctx context.Context, selector Selector) (sharedCriterions []SharedCriterion, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		sharedCriterionServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return sharedCriterions, totalCount, err
+	}
+	getResp := struct {
+		Size             int64             `xml:"rval>totalNumEntries"`
+		SharedCriterions []SharedCriterion `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return sharedCriterions, totalCount, err
+	}
+	return getResp.SharedCriterions, getResp.Size, err
+}
+
+// Mutate allows you to add and remove shared criteria, returning the
+// modified shared criteria.
+//
+// Example
+//
+//  sharedCriterions, err := sharedCriterionService.Mutate(
+//    SharedCriterionOperations{
+//      "ADD": {
+//        SharedCriterion{
+//          SharedSetId: 1234,
+//          Criterion:   KeywordCriterion{Text: "free", MatchType: "BROAD"},
+//          Negative:    true,
+//        },
+//      },
+//      "REMOVE": {
+//        SharedCriterion{SharedSetId: 1234, Criterion: KeywordCriterion{Id: 10}},
+//      },
+//    },
+//  )
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/SharedCriterionService#mutate
+//
+func (s *SharedCriterionService) Mutate(sharedCriterionOperations SharedCriterionOperations) (sharedCriterions []SharedCriterion, err error) {
+	return s.MutateContext(context.Background(), sharedCriterionOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *SharedCriterionService) MutateContext(ctx context.Context, sharedCriterionOperations SharedCriterionOperations) (sharedCriterions []SharedCriterion, err error) {
+	type sharedCriterionOperation struct {
+		Action          string          `xml:"operator"`
+		SharedCriterion SharedCriterion `xml:"operand"`
+	}
+	operations := []sharedCriterionOperation{}
+	for action, sharedCriterions := range sharedCriterionOperations {
+		for _, sharedCriterion := range sharedCriterions {
+			operations = append(operations,
+				sharedCriterionOperation{
+					Action:          action,
+					SharedCriterion: sharedCriterion,
+				},
+			)
+		}
+	}
+	mutation := struct {
+		XMLName xml.Name
+		Ops     []sharedCriterionOperation `xml:"operations"`
+	}{
+		XMLName: xml.Name{
+			Space: baseUrl,
+			Local: "mutate",
+		},
+		Ops: operations,
+	}
+	respBody, err := s.Auth.request(ctx, sharedCriterionServiceUrl, "mutate", mutation)
+	if err != nil {
+		return sharedCriterions, err
+	}
+	mutateResp := struct {
+		BaseResponse
+		SharedCriterions []SharedCriterion `xml:"rval>value"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &mutateResp)
+	if err != nil {
+		return sharedCriterions, err
+	}
+
+	if len(mutateResp.PartialFailureErrors) > 0 {
+		err = mutateResp.PartialFailureErrors
+	}
+
+	return mutateResp.SharedCriterions, err
+}
+
+// Query returns the shared criteria matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   sharedCriterions, totalCount, err := sharedCriterionService.Query("SELECT Id, KeywordText WHERE SharedSetId = 1234")
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/SharedCriterionService#query
+//
+func (s *SharedCriterionService) Query(query string) (sharedCriterions []SharedCriterion, totalCount int64, err error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *SharedCriterionService) QueryContext(ctx context.Context, query string) (sharedCriterions []SharedCriterion, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		sharedCriterionServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return sharedCriterions, totalCount, err
+	}
+	queryResp := struct {
+		Size             int64             `xml:"rval>totalNumEntries"`
+		SharedCriterions []SharedCriterion `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return sharedCriterions, totalCount, err
+	}
+	return queryResp.SharedCriterions, queryResp.Size, err
+}
+
+// sharedCriterionKey identifies a criterion by its content, so that a
+// desired criterion without id matches the live one. A nil criterion,
+// of a type not modelled by the package, has no key.
+func sharedCriterionKey(c Criterion) string {
+	switch c := c.(type) {
+	case nil:
+		return ""
+	case KeywordCriterion:
+		return "Keyword:" + strings.ToUpper(c.MatchType) + ":" + strings.ToLower(strings.Join(strings.Fields(c.Text), " "))
+	case PlacementCriterion:
+		return "Placement:" + strings.TrimSuffix(strings.ToLower(strings.TrimSpace(c.Url)), "/")
+	case MobileAppCategoryCriterion:
+		return "MobileAppCategory:" + strconv.FormatInt(c.MobileAppCategoryId, 10)
+	case MobileApplicationCriterion:
+		return "MobileApplication:" + c.AppId
+	}
+	return fmt.Sprintf("%T:%d", c, c.GetID())
+}
+
+// DiffSharedCriteria returns the operations turning the live criteria of
+// the shared set sharedSetId into the desired ones: the live criteria
+// missing from desired are removed, the desired criteria missing from
+// live are added as negative criteria. Keywords are compared by match
+// type and case insensitive text, placements by case insensitive url,
+// other criteria by id. Duplicates of live criteria are removed. Live
+// criteria of a type not modelled by the package, such as YouTube channel
+// or video exclusions, are left alone.
+func DiffSharedCriteria(sharedSetId int64, live []SharedCriterion, desired []Criterion) SharedCriterionOperations {
+	wanted := map[string]bool{}
+	for _, c := range desired {
+		wanted[sharedCriterionKey(c)] = true
+	}
+
+	operations := SharedCriterionOperations{}
+	kept := map[string]bool{}
+	for _, sc := range live {
+		if sc.Criterion == nil {
+			continue
+		}
+		key := sharedCriterionKey(sc.Criterion)
+		if !wanted[key] || kept[key] {
+			operations["REMOVE"] = append(operations["REMOVE"], SharedCriterion{
+				SharedSetId: sharedSetId,
+				Criterion:   sc.Criterion,
+			})
+			continue
+		}
+		kept[key] = true
+	}
+	for _, c := range desired {
+		if c == nil {
+			continue
+		}
+		key := sharedCriterionKey(c)
+		if kept[key] {
+			continue
+		}
+		kept[key] = true
+		operations["ADD"] = append(operations["ADD"], SharedCriterion{
+			SharedSetId: sharedSetId,
+			Criterion:   c,
+			Negative:    true,
+		})
+	}
+	return operations
+}
+
+// reconcileBatchSize is the number of operations of each mutate sent by
+// Reconcile, the most the api accepts in a request.
+var reconcileBatchSize = 5000
+
+// Reconcile makes the criteria of the negative shared set sharedSetId
+// match desired, see DiffSharedCriteria, and returns the operations
+// applied. Nothing is mutated when the set is already in sync, and
+// nothing is applied with Auth.ValidateOnly, making it a dry run.
+//
+// The removals are sent first, so that a criterion removed and added back
+// with another id never exceeds the size of the set, then the additions,
+// in mutates of at most 5000 operations. When a mutate fails, the
+// operations of the previous ones are returned along with the error.
+//
+// Example
+//
+//   operations, err := sharedCriterionService.Reconcile(ctx, 1234, []gads.Criterion{
+//     gads.KeywordCriterion{Text: "free", MatchType: "BROAD"},
+//     gads.KeywordCriterion{Text: "cheap flights", MatchType: "EXACT"},
+//   })
+//   fmt.Printf("%d added, %d removed\n", len(operations["ADD"]), len(operations["REMOVE"]))
+//
+func (s *SharedCriterionService) Reconcile(ctx context.Context, sharedSetId int64, desired []Criterion) (operations SharedCriterionOperations, err error) {
+	pager := NewPager(ctx, s.GetContext, Selector{
+		Fields: []string{
+			"Id", "CriteriaType", "KeywordText", "KeywordMatchType",
+			"PlacementUrl", "MobileAppCategoryId", "AppId", "SharedSetId",
+		},
+		Predicates: []Predicate{
+			{"SharedSetId", "EQUALS", []string{strconv.FormatInt(sharedSetId, 10)}},
+		},
+	})
+	live := []SharedCriterion{}
+	for pager.Next() {
+		live = append(live, pager.Entry())
+	}
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+
+	diff := DiffSharedCriteria(sharedSetId, live, desired)
+	operations = SharedCriterionOperations{}
+	for _, operator := range []string{"REMOVE", "ADD"} {
+		pending := diff[operator]
+		for len(pending) > 0 {
+			n := len(pending)
+			if n > reconcileBatchSize {
+				n = reconcileBatchSize
+			}
+			if _, err = s.MutateContext(ctx, SharedCriterionOperations{operator: pending[:n]}); err != nil {
+				return operations, err
+			}
+			operations[operator] = append(operations[operator], pending[:n]...)
+			pending = pending[n:]
+		}
+	}
+	return operations, nil
+}
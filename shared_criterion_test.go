@@ -0,0 +1,111 @@
+package gads
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testSharedCriterionGetResponse = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <getResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+      <rval>
+        <totalNumEntries>5</totalNumEntries>
+        <entries><sharedSetId>77</sharedSetId><criterion xsi:type="Keyword"><id>1</id><type>KEYWORD</type><text>Free</text><matchType>BROAD</matchType></criterion><negative>true</negative></entries>
+        <entries><sharedSetId>77</sharedSetId><criterion xsi:type="Keyword"><id>2</id><type>KEYWORD</type><text>jobs</text><matchType>PHRASE</matchType></criterion><negative>true</negative></entries>
+        <entries><sharedSetId>77</sharedSetId><criterion xsi:type="Keyword"><id>3</id><type>KEYWORD</type><text>free</text><matchType>BROAD</matchType></criterion><negative>true</negative></entries>
+        <entries><sharedSetId>77</sharedSetId><criterion xsi:type="Placement"><id>4</id><type>PLACEMENT</type><url>Example.com/</url></criterion><negative>true</negative></entries>
+        <entries><sharedSetId>77</sharedSetId><criterion xsi:type="YouTubeChannel"><id>5</id><type>YOUTUBE_CHANNEL</type><channelId>UC123</channelId></criterion><negative>true</negative></entries>
+      </rval>
+    </getResponse>
+  </soap:Body>
+</soap:Envelope>`
+
+func TestSharedCriterionReconcile(t *testing.T) {
+	var mutations []string
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), "<operations>") {
+			fmt.Fprint(w, testSharedCriterionGetResponse)
+			return
+		}
+		mutations = append(mutations, string(body))
+		fmt.Fprint(w, strings.Replace(testEmptyGetResponse, "getResponse", "mutateResponse", -1))
+	})
+
+	operations, err := NewSharedCriterionService(&auth).Reconcile(context.Background(), 77, []Criterion{
+		KeywordCriterion{Text: "free ", MatchType: "BROAD"},
+		KeywordCriterion{Text: "cheap  flights", MatchType: "EXACT"},
+		KeywordCriterion{Text: "Cheap flights", MatchType: "EXACT"},
+		PlacementCriterion{Url: "example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the YouTube channel, not modelled, is left alone
+	var removed []int64
+	for _, sc := range operations["REMOVE"] {
+		if sc.Criterion == nil {
+			t.Fatalf("unexpected removal of a nil criterion %+v", sc)
+		}
+		removed = append(removed, sc.Criterion.GetID())
+	}
+	if fmt.Sprint(removed) != "[2 3]" {
+		t.Errorf("expected the jobs keyword and the duplicate to be removed, got %v", removed)
+	}
+	added := operations["ADD"]
+	if len(added) != 1 || added[0].Criterion.(KeywordCriterion).Text != "cheap  flights" || !added[0].Negative || added[0].SharedSetId != 77 {
+		t.Errorf("unexpected additions %+v", added)
+	}
+	mutation := strings.Join(mutations, "")
+	for _, want := range []string{"<operator>REMOVE</operator>", "<id>3</id>", "<text>cheap  flights</text>", "<negative>true</negative>"} {
+		if !strings.Contains(mutation, want) {
+			t.Errorf("%s not sent in %s", want, mutation)
+		}
+	}
+	if len(mutations) != 2 || !strings.Contains(mutations[0], "<operator>REMOVE</operator>") || !strings.Contains(mutations[1], "<operator>ADD</operator>") {
+		t.Errorf("expected a mutate removing then one adding, got %d", len(mutations))
+	}
+
+	// the operations are sent in batches, the removals first
+	defer func(size int) { reconcileBatchSize = size }(reconcileBatchSize)
+	reconcileBatchSize = 1
+	mutations = nil
+	if _, err := NewSharedCriterionService(&auth).Reconcile(context.Background(), 77, []Criterion{
+		KeywordCriterion{Text: "free", MatchType: "BROAD"},
+		KeywordCriterion{Text: "cheap flights", MatchType: "EXACT"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var operators []string
+	for _, m := range mutations {
+		if strings.Count(m, "<operations>") != 1 {
+			t.Errorf("expected a single operation per mutate, got %s", m)
+		}
+		for _, operator := range []string{"REMOVE", "ADD"} {
+			if strings.Contains(m, "<operator>"+operator+"</operator>") {
+				operators = append(operators, operator)
+			}
+		}
+	}
+	if strings.Join(operators, ",") != "REMOVE,REMOVE,REMOVE,ADD" {
+		t.Errorf("unexpected mutates %v", operators)
+	}
+
+	mutations = nil
+	operations, err = NewSharedCriterionService(&auth).Reconcile(context.Background(), 77, []Criterion{
+		KeywordCriterion{Text: "free", MatchType: "BROAD"},
+		KeywordCriterion{Text: "jobs", MatchType: "PHRASE"},
+		PlacementCriterion{Url: "example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(operations["ADD"]) != 0 || len(operations["REMOVE"]) != 1 {
+		t.Errorf("expected only the duplicate to be removed, got %+v", operations)
+	}
+}
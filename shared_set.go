@@ -1,5 +1,10 @@
 package gads
 
+import (
+	"context"
+	"encoding/xml"
+)
+
 type SharedSetService struct {
 	Auth
 }
@@ -7,3 +12,219 @@ type SharedSetService struct {
 func NewSharedSetService(auth *Auth) *SharedSetService {
 	return &SharedSetService{Auth: *auth}
 }
+
+// Shared set types
+const (
+	SharedSetTypeNegativeKeywords   = "NEGATIVE_KEYWORDS"
+	SharedSetTypeNegativePlacements = "NEGATIVE_PLACEMENTS"
+)
+
+// SharedSet is a list of criteria shared by campaigns, like a negative
+// keyword list. Its criteria are managed with SharedCriterionService and
+// it is attached to campaigns with CampaignSharedSetService.
+//
+// The type of the set is SetType, a Type field would be taken for its
+// xsi type.
+type SharedSet struct {
+	SharedSetId    int64  `xml:"sharedSetId,omitempty"`
+	Name           string `xml:"name,omitempty"`
+	SetType        string `xml:"type,omitempty"` // SetType: "NEGATIVE_KEYWORDS", "NEGATIVE_PLACEMENTS"
+	MemberCount    int64  `xml:"memberCount,omitempty"`
+	ReferenceCount int64  `xml:"referenceCount,omitempty"`
+	Status         string `xml:"status,omitempty"` // Status: "ENABLED", "REMOVED"
+}
+
+// SharedSetOperations is a map of operations to perform on SharedSet's
+type SharedSetOperations map[string][]SharedSet
+
+// SharedSetFields is the catalog of the fields accepted by the selectors
+// of SharedSetService.Get, see NewSelector.
+var SharedSetFields = FieldCatalog{
+	Service: "SharedSetService",
+	Selectable: []string{
+		"MemberCount", "Name", "ReferenceCount", "SharedSetId", "Status",
+		"Type",
+	},
+	Filterable: []string{
+		"MemberCount", "Name", "ReferenceCount", "SharedSetId", "Status",
+		"Type",
+	},
+	Complete: true,
+}
+
+// Get returns an array of SharedSet's and the total number of SharedSet's
+// matching the selector.
+//
+// Example
+//
+//   sharedSets, totalCount, err := sharedSetService.Get(
+//     Selector{
+//       Fields: []string{"SharedSetId","Name","Type","MemberCount"},
+//       Predicates: []Predicate{
+//         {"Type", "EQUALS", []string{"NEGATIVE_KEYWORDS"}},
+//         {"Status", "EQUALS", []string{"ENABLED"}},
+//       },
+//     },
+//   )
+//
+// Selectable fields are
+//   "MemberCount", "Name", "ReferenceCount", "SharedSetId", "Status",
+//   "Type"
+//
+// filterable fields are
+//   "MemberCount", "Name", "ReferenceCount", "SharedSetId", "Status",
+//   "Type"
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/SharedSetService#get
+//
+func (s *SharedSetService) Get(selector Selector) (sharedSets []SharedSet, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *SharedSetService) GetContext(ctx context.Context, selector Selector) (sharedSets []SharedSet, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		sharedSetServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return sharedSets, totalCount, err
+	}
+	getResp := struct {
+		Size       int64       `xml:"rval>totalNumEntries"`
+		SharedSets []SharedSet `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return sharedSets, totalCount, err
+	}
+	return getResp.SharedSets, getResp.Size, err
+}
+
+// Mutate allows you to add, rename and remove shared sets, returning the
+// modified shared sets.
+//
+// Example
+//
+//  sharedSets, err := sharedSetService.Mutate(
+//    SharedSetOperations{
+//      "ADD": {
+//        SharedSet{Name: "brand negatives", SetType: SharedSetTypeNegativeKeywords},
+//      },
+//      "REMOVE": {
+//        SharedSet{SharedSetId: 10},
+//      },
+//    },
+//  )
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/SharedSetService#mutate
+//
+func (s *SharedSetService) Mutate(sharedSetOperations SharedSetOperations) (sharedSets []SharedSet, err error) {
+	return s.MutateContext(context.Background(), sharedSetOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *SharedSetService) MutateContext(ctx context.Context, sharedSetOperations SharedSetOperations) (sharedSets []SharedSet, err error) {
+	type sharedSetOperation struct {
+		Action    string    `xml:"operator"`
+		SharedSet SharedSet `xml:"operand"`
+	}
+	operations := []sharedSetOperation{}
+	for action, sharedSets := range sharedSetOperations {
+		for _, sharedSet := range sharedSets {
+			operations = append(operations,
+				sharedSetOperation{
+					Action:    action,
+					SharedSet: sharedSet,
+				},
+			)
+		}
+	}
+	mutation := struct {
+		XMLName xml.Name
+		Ops     []sharedSetOperation `xml:"operations"`
+	}{
+		XMLName: xml.Name{
+			Space: baseUrl,
+			Local: "mutate",
+		},
+		Ops: operations,
+	}
+	respBody, err := s.Auth.request(ctx, sharedSetServiceUrl, "mutate", mutation)
+	if err != nil {
+		return sharedSets, err
+	}
+	mutateResp := struct {
+		BaseResponse
+		SharedSets []SharedSet `xml:"rval>value"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &mutateResp)
+	if err != nil {
+		return sharedSets, err
+	}
+
+	if len(mutateResp.PartialFailureErrors) > 0 {
+		err = mutateResp.PartialFailureErrors
+	}
+
+	return mutateResp.SharedSets, err
+}
+
+// Query returns the shared sets matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   sharedSets, totalCount, err := sharedSetService.Query("SELECT SharedSetId, Name WHERE Type = 'NEGATIVE_KEYWORDS'")
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/SharedSetService#query
+//
+func (s *SharedSetService) Query(query string) (sharedSets []SharedSet, totalCount int64, err error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *SharedSetService) QueryContext(ctx context.Context, query string) (sharedSets []SharedSet, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		sharedSetServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return sharedSets, totalCount, err
+	}
+	queryResp := struct {
+		Size       int64       `xml:"rval>totalNumEntries"`
+		SharedSets []SharedSet `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return sharedSets, totalCount, err
+	}
+	return queryResp.SharedSets, queryResp.Size, err
+}
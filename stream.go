@@ -0,0 +1,167 @@
+package gads
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const soapEnvelopeNamespace = "http://schemas.xmlsoap.org/soap/envelope/"
+
+// ErrStopStream can be returned, or wrapped, by the callback of a streamed
+// get to stop reading the response without failing the call.
+var ErrStopStream = errors.New("stop stream")
+
+// decodeStream reads the soap response of a streamed call from the http
+// body, handing the response element to call.stream as soon as it is
+// reached instead of buffering the whole envelope.
+func (a *Auth) decodeStream(ctx context.Context, call *SoapCall, resp *http.Response) error {
+	dec := xml.NewDecoder(resp.Body)
+	var header ResponseHeader
+	inEnvelope, inBody := false, false
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if !inEnvelope && resp.StatusCode >= 500 {
+				// gateway and overload errors don't come with a soap envelope
+				return &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+			}
+			return err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case !inEnvelope:
+			if start.Name.Space != soapEnvelopeNamespace || start.Name.Local != "Envelope" {
+				if resp.StatusCode >= 500 {
+					return &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+				}
+				return fmt.Errorf("expected a soap envelope, got %s", start.Name.Local)
+			}
+			inEnvelope = true
+		case !inBody && start.Name.Local == "Header":
+			// descend into the header
+		case !inBody && start.Name.Local == "ResponseHeader":
+			if err := dec.DecodeElement(&header, &start); err != nil {
+				return err
+			}
+			call.ResponseHeader = header
+			a.handleResponseHeader(ctx, header)
+		case !inBody && start.Name.Space == soapEnvelopeNamespace && start.Name.Local == "Body":
+			inBody = true
+		case !inBody:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		case start.Name.Local == "Fault":
+			fault := Fault{}
+			if err := dec.DecodeElement(&fault, &start); err != nil {
+				return err
+			}
+			fault.Errors.Header = header
+			return &fault.Errors
+		default:
+			call.streamed = true
+			return call.stream(dec, start)
+		}
+	}
+}
+
+// streamRval walks the rval of a get response, decoding totalNumEntries
+// into totalCount and handing every entries element to entry.
+func streamRval(
+	dec *xml.Decoder,
+	totalCount *int64,
+	entry func(dec *xml.Decoder, start xml.StartElement) error,
+) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.EndElement:
+			if depth == 0 {
+				// end of the response element
+				return nil
+			}
+			depth--
+		case xml.StartElement:
+			switch {
+			case depth == 0 && t.Name.Local == "rval":
+				depth++
+			case depth == 1 && t.Name.Local == "totalNumEntries":
+				if err := dec.DecodeElement(totalCount, &t); err != nil {
+					return err
+				}
+			case depth == 1 && t.Name.Local == "entries":
+				if err := entry(dec, t); err != nil {
+					if errors.Is(err, ErrStopStream) {
+						return nil
+					}
+					return err
+				}
+			default:
+				if err := dec.Skip(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// streamGet performs a get-like call whose entries are decoded one at a
+// time as they are read from the http body. Each entries element is
+// decoded into a fresh S, so the polymorphic slice types of the package
+// keep deciding which concrete type an entry has, and its elements are
+// handed to fn.
+func streamGet[S ~[]T, T any](
+	ctx context.Context,
+	a *Auth,
+	serviceUrl ServiceUrl,
+	action string,
+	body interface{},
+	fn func(T) error,
+) (totalCount int64, err error) {
+	call, err := a.newSoapCall(serviceUrl, action, body)
+	if err != nil {
+		return 0, err
+	}
+	call.stream = func(dec *xml.Decoder, start xml.StartElement) error {
+		return streamRval(dec, &totalCount, func(dec *xml.Decoder, start xml.StartElement) error {
+			var entries S
+			if err := dec.DecodeElement(&entries, &start); err != nil {
+				return err
+			}
+			for _, e := range entries {
+				if err := fn(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	err = a.do(ctx, call)
+	return totalCount, err
+}
+
+// getSelector is the body of the get calls of most services.
+func getSelector(serviceUrl ServiceUrl, selector Selector) interface{} {
+	selector.XMLName = xml.Name{"", "serviceSelector"}
+	return struct {
+		XMLName xml.Name
+		Sel     Selector
+	}{
+		XMLName: xml.Name{
+			Space: serviceUrl.Url,
+			Local: "get",
+		},
+		Sel: selector,
+	}
+}
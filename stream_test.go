@@ -0,0 +1,178 @@
+package gads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testAdGroupCriterionGetResponse = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Header>
+    <ResponseHeader xmlns="https://adwords.google.com/api/adwords/cm/v201809">
+      <requestId>0005a1b2c3d6</requestId>
+      <serviceName>AdGroupCriterionService</serviceName>
+      <methodName>get</methodName>
+      <operations>2</operations>
+      <responseTime>42</responseTime>
+    </ResponseHeader>
+  </soap:Header>
+  <soap:Body>
+    <getResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809">
+      <rval>
+        <totalNumEntries>3</totalNumEntries>
+        <Page.Type>AdGroupCriterionPage</Page.Type>
+        <entries xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="BiddableAdGroupCriterion">
+          <adGroupId>1</adGroupId>
+          <criterionUse>BIDDABLE</criterionUse>
+          <criterion xsi:type="Keyword">
+            <id>11</id>
+            <type>KEYWORD</type>
+            <Criterion.Type>Keyword</Criterion.Type>
+            <text>red shoes</text>
+            <matchType>EXACT</matchType>
+          </criterion>
+          <userStatus>ENABLED</userStatus>
+        </entries>
+        <entries xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="NegativeAdGroupCriterion">
+          <adGroupId>1</adGroupId>
+          <criterionUse>NEGATIVE</criterionUse>
+          <criterion xsi:type="Keyword">
+            <id>12</id>
+            <type>KEYWORD</type>
+            <Criterion.Type>Keyword</Criterion.Type>
+            <text>blue shoes</text>
+            <matchType>BROAD</matchType>
+          </criterion>
+        </entries>
+        <entries xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="BiddableAdGroupCriterion">
+          <adGroupId>1</adGroupId>
+          <criterionUse>BIDDABLE</criterionUse>
+          <criterion xsi:type="Keyword">
+            <id>13</id>
+            <type>KEYWORD</type>
+            <Criterion.Type>Keyword</Criterion.Type>
+            <text>green shoes</text>
+            <matchType>PHRASE</matchType>
+          </criterion>
+          <userStatus>PAUSED</userStatus>
+        </entries>
+      </rval>
+    </getResponse>
+  </soap:Body>
+</soap:Envelope>`
+
+func TestStreamEntries(t *testing.T) {
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testAdGroupCriterionGetResponse)
+	})
+	var header ResponseHeader
+	auth.OnResponseHeader = func(h ResponseHeader) { header = h }
+
+	var texts []string
+	totalCount, err := NewAdGroupCriterionService(&auth).ForEach(
+		context.Background(),
+		Selector{Fields: []string{"Id", "KeywordText"}},
+		func(criterion interface{}) error {
+			switch c := criterion.(type) {
+			case BiddableAdGroupCriterion:
+				texts = append(texts, c.Criterion.(KeywordCriterion).Text)
+			case NegativeAdGroupCriterion:
+				texts = append(texts, "-"+c.Criterion.(KeywordCriterion).Text)
+			default:
+				t.Errorf("unexpected criterion %#v", criterion)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if totalCount != 3 {
+		t.Errorf("expected 3 entries in total, got %d", totalCount)
+	}
+	if fmt.Sprint(texts) != "[red shoes -blue shoes green shoes]" {
+		t.Errorf("unexpected entries %v", texts)
+	}
+	if header.RequestId != "0005a1b2c3d6" {
+		t.Errorf("expected the response header to be handled, got %#v", header)
+	}
+}
+
+func TestStreamStop(t *testing.T) {
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testAdGroupCriterionGetResponse)
+	})
+	as := NewAdGroupCriterionService(&auth)
+
+	seen := 0
+	_, err := as.ForEach(context.Background(), Selector{}, func(interface{}) error {
+		seen++
+		return ErrStopStream
+	})
+	if err != nil || seen != 1 {
+		t.Errorf("expected to stop after 1 entry without error, got %d entries and %v", seen, err)
+	}
+
+	seen = 0
+	_, err = as.ForEach(context.Background(), Selector{}, func(interface{}) error {
+		seen++
+		return fmt.Errorf("enough entries: %w", ErrStopStream)
+	})
+	if err != nil || seen != 1 {
+		t.Errorf("expected a wrapped ErrStopStream to stop after 1 entry, got %d entries and %v", seen, err)
+	}
+
+	boom := errors.New("boom")
+	_, err = as.ForEach(context.Background(), Selector{}, func(interface{}) error {
+		return boom
+	})
+	if err != boom {
+		t.Errorf("expected the callback error, got %v", err)
+	}
+}
+
+func TestStreamErrors(t *testing.T) {
+	calls := 0
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, testRateExceededFault)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, "<html>bad gateway</html>")
+		default:
+			fmt.Fprint(w, testAdGroupCriterionGetResponse)
+		}
+	})
+	auth.Retry = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	var faults *ErrorsType
+	_, err := NewAdGroupCriterionService(&Auth{Client: auth.Client}).ForEach(context.Background(), Selector{}, func(interface{}) error { return nil })
+	if !errors.As(err, &faults) {
+		t.Fatalf("expected a soap fault, got %#v", err)
+	}
+	var statusErr *HTTPStatusError
+	_, err = NewAdGroupCriterionService(&Auth{Client: auth.Client}).ForEach(context.Background(), Selector{}, func(interface{}) error { return nil })
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected an HTTPStatusError, got %#v", err)
+	}
+
+	// the retry policy applies until entries are handed out
+	calls = 0
+	seen := 0
+	_, err = NewAdGroupCriterionService(&auth).ForEach(context.Background(), Selector{}, func(interface{}) error {
+		seen++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 || seen != 3 {
+		t.Errorf("expected 3 calls and 3 entries, got %d calls and %d entries", calls, seen)
+	}
+}
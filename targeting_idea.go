@@ -1,5 +1,11 @@
 package gads
 
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+)
+
 type TargetIdeaService struct {
 	Auth
 }
@@ -7,3 +13,494 @@ type TargetIdeaService struct {
 func NewTargetIdeaService(auth *Auth) *TargetIdeaService {
 	return &TargetIdeaService{Auth: *auth}
 }
+
+// Targeting idea request types
+const (
+	RequestTypeIdeas = "IDEAS"
+	RequestTypeStats = "STATS"
+)
+
+// Attribute types of a targeting idea
+const (
+	AttributeTypeKeywordText                 = "KEYWORD_TEXT"
+	AttributeTypeSearchVolume                = "SEARCH_VOLUME"
+	AttributeTypeTargetedMonthlySearches     = "TARGETED_MONTHLY_SEARCHES"
+	AttributeTypeAverageCpc                  = "AVERAGE_CPC"
+	AttributeTypeCompetition                 = "COMPETITION"
+	AttributeTypeCategoryProductsAndServices = "CATEGORY_PRODUCTS_AND_SERVICES"
+	AttributeTypeIdeaType                    = "IDEA_TYPE"
+	AttributeTypeExtractedFromWebpage        = "EXTRACTED_FROM_WEBPAGE"
+)
+
+// SearchParameter is a parameter of a targeting idea search, one of the
+// *SearchParameter types.
+type SearchParameter interface {
+	GetType() string
+}
+
+// RelatedToQuerySearchParameter searches ideas related to queries, like
+// seed keywords.
+type RelatedToQuerySearchParameter struct {
+	Type    string   `xml:"xsi:type,attr,omitempty"`
+	Queries []string `xml:"queries"`
+}
+
+func (p RelatedToQuerySearchParameter) GetType() string {
+	return "RelatedToQuerySearchParameter"
+}
+
+// RelatedToUrlSearchParameter searches ideas related to the content of
+// web pages.
+type RelatedToUrlSearchParameter struct {
+	Type           string   `xml:"xsi:type,attr,omitempty"`
+	Urls           []string `xml:"urls"`
+	IncludeSubUrls bool     `xml:"includeSubUrls"`
+}
+
+func (p RelatedToUrlSearchParameter) GetType() string {
+	return "RelatedToUrlSearchParameter"
+}
+
+// LanguageSearchParameter restricts the statistics to languages, only the
+// Id of the criteria is used.
+type LanguageSearchParameter struct {
+	Type      string              `xml:"xsi:type,attr,omitempty"`
+	Languages []LanguageCriterion `xml:"languages"`
+}
+
+func (p LanguageSearchParameter) GetType() string {
+	return "LanguageSearchParameter"
+}
+
+// LocationSearchParameter restricts the statistics to locations, only the
+// Id of the locations is used.
+type LocationSearchParameter struct {
+	Type      string     `xml:"xsi:type,attr,omitempty"`
+	Locations []Location `xml:"locations"`
+}
+
+func (p LocationSearchParameter) GetType() string {
+	return "LocationSearchParameter"
+}
+
+// NetworkSearchParameter restricts the statistics to networks, either
+// Google search or Google search and the search partners.
+type NetworkSearchParameter struct {
+	Type           string         `xml:"xsi:type,attr,omitempty"`
+	NetworkSetting NetworkSetting `xml:"networkSetting"`
+}
+
+func (p NetworkSearchParameter) GetType() string {
+	return "NetworkSearchParameter"
+}
+
+// LongComparisonOperation bounds a value, a nil bound is open.
+type LongComparisonOperation struct {
+	Minimum *int64 `xml:"minimum,omitempty"`
+	Maximum *int64 `xml:"maximum,omitempty"`
+}
+
+// SearchVolumeSearchParameter filters the ideas on their average monthly
+// search volume.
+type SearchVolumeSearchParameter struct {
+	Type      string                  `xml:"xsi:type,attr,omitempty"`
+	Operation LongComparisonOperation `xml:"operation"`
+}
+
+func (p SearchVolumeSearchParameter) GetType() string {
+	return "SearchVolumeSearchParameter"
+}
+
+// CompetitionSearchParameter filters the ideas on their competition level.
+type CompetitionSearchParameter struct {
+	Type   string   `xml:"xsi:type,attr,omitempty"`
+	Levels []string `xml:"levels"` // Levels: "LOW", "MEDIUM", "HIGH", "UNKNOWN"
+}
+
+func (p CompetitionSearchParameter) GetType() string {
+	return "CompetitionSearchParameter"
+}
+
+// TargetingIdeaSelector describes the ideas requested from
+// TargetIdeaService.Get.
+type TargetingIdeaSelector struct {
+	XMLName                 xml.Name
+	SearchParameters        []SearchParameter `xml:"searchParameters"`
+	IdeaType                string            `xml:"ideaType"`    // IdeaType: "KEYWORD", "PLACEMENT"
+	RequestType             string            `xml:"requestType"` // RequestType: "IDEAS", "STATS"
+	RequestedAttributeTypes []string          `xml:"requestedAttributeTypes"`
+	Paging                  *Paging           `xml:"paging,omitempty"`
+	LocaleCode              string            `xml:"localeCode,omitempty"`
+	CurrencyCode            string            `xml:"currencyCode,omitempty"`
+}
+
+// Attribute is a value of a targeting idea, one of the *Attribute types.
+type Attribute interface {
+	GetType() string
+}
+
+type BooleanAttribute struct {
+	Value bool `xml:"value"`
+}
+
+func (a BooleanAttribute) GetType() string {
+	return "BooleanAttribute"
+}
+
+type DoubleAttribute struct {
+	Value float64 `xml:"value"`
+}
+
+func (a DoubleAttribute) GetType() string {
+	return "DoubleAttribute"
+}
+
+type IdeaTypeAttribute struct {
+	Value string `xml:"value"` // Value: "KEYWORD", "PLACEMENT"
+}
+
+func (a IdeaTypeAttribute) GetType() string {
+	return "IdeaTypeAttribute"
+}
+
+type IntegerAttribute struct {
+	Value int `xml:"value"`
+}
+
+func (a IntegerAttribute) GetType() string {
+	return "IntegerAttribute"
+}
+
+type IntegerSetAttribute struct {
+	Value []int `xml:"value"`
+}
+
+func (a IntegerSetAttribute) GetType() string {
+	return "IntegerSetAttribute"
+}
+
+type KeywordAttribute struct {
+	Value KeywordCriterion `xml:"value"`
+}
+
+func (a KeywordAttribute) GetType() string {
+	return "KeywordAttribute"
+}
+
+type LongAttribute struct {
+	Value int64 `xml:"value"`
+}
+
+func (a LongAttribute) GetType() string {
+	return "LongAttribute"
+}
+
+// MoneyAttribute is an amount in micros of the account currency.
+type MoneyAttribute struct {
+	Value int64 `xml:"value>microAmount"`
+}
+
+func (a MoneyAttribute) GetType() string {
+	return "MoneyAttribute"
+}
+
+// MonthlySearchVolume is the number of searches during a month.
+type MonthlySearchVolume struct {
+	Year  int   `xml:"year"`
+	Month int   `xml:"month"`
+	Count int64 `xml:"count"`
+}
+
+type MonthlySearchVolumeAttribute struct {
+	Value []MonthlySearchVolume `xml:"value"`
+}
+
+func (a MonthlySearchVolumeAttribute) GetType() string {
+	return "MonthlySearchVolumeAttribute"
+}
+
+type StringAttribute struct {
+	Value string `xml:"value"`
+}
+
+func (a StringAttribute) GetType() string {
+	return "StringAttribute"
+}
+
+// WebpageDescriptor is the page an idea was extracted from.
+type WebpageDescriptor struct {
+	Url   string `xml:"url"`
+	Title string `xml:"title"`
+}
+
+type WebpageDescriptorAttribute struct {
+	Value WebpageDescriptor `xml:"value"`
+}
+
+func (a WebpageDescriptorAttribute) GetType() string {
+	return "WebpageDescriptorAttribute"
+}
+
+func attributeUnmarshalXML(dec *xml.Decoder, start xml.StartElement) (Attribute, error) {
+	attributeType, err := findAttr(start.Attr, xml.Name{Space: "http://www.w3.org/2001/XMLSchema-instance", Local: "type"})
+	if err != nil {
+		return nil, err
+	}
+	switch attributeType {
+	case "BooleanAttribute":
+		a := BooleanAttribute{}
+		err := dec.DecodeElement(&a, &start)
+		return a, err
+	case "DoubleAttribute":
+		a := DoubleAttribute{}
+		err := dec.DecodeElement(&a, &start)
+		return a, err
+	case "IdeaTypeAttribute":
+		a := IdeaTypeAttribute{}
+		err := dec.DecodeElement(&a, &start)
+		return a, err
+	case "IntegerAttribute":
+		a := IntegerAttribute{}
+		err := dec.DecodeElement(&a, &start)
+		return a, err
+	case "IntegerSetAttribute":
+		a := IntegerSetAttribute{}
+		err := dec.DecodeElement(&a, &start)
+		return a, err
+	case "KeywordAttribute":
+		a := KeywordAttribute{}
+		err := dec.DecodeElement(&a, &start)
+		return a, err
+	case "LongAttribute":
+		a := LongAttribute{}
+		err := dec.DecodeElement(&a, &start)
+		return a, err
+	case "MoneyAttribute":
+		a := MoneyAttribute{}
+		err := dec.DecodeElement(&a, &start)
+		return a, err
+	case "MonthlySearchVolumeAttribute":
+		a := MonthlySearchVolumeAttribute{}
+		err := dec.DecodeElement(&a, &start)
+		return a, err
+	case "StringAttribute":
+		a := StringAttribute{}
+		err := dec.DecodeElement(&a, &start)
+		return a, err
+	case "WebpageDescriptorAttribute":
+		a := WebpageDescriptorAttribute{}
+		err := dec.DecodeElement(&a, &start)
+		return a, err
+	default:
+		if StrictMode {
+			return nil, fmt.Errorf("unknown attribute type %#v", attributeType)
+		}
+		return nil, dec.Skip()
+	}
+}
+
+// TargetingIdea maps the requested attribute types to their value, the
+// typed accessors return the zero value of the attributes not requested.
+type TargetingIdea struct {
+	Data map[string]Attribute
+}
+
+func (ti *TargetingIdea) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	ti.Data = map[string]Attribute{}
+	for token, err := dec.Token(); err == nil; token, err = dec.Token() {
+		if err != nil {
+			return err
+		}
+		switch start := token.(type) {
+		case xml.StartElement:
+			tag := start.Name.Local
+			switch tag {
+			case "data":
+				entry := targetingIdeaEntry{}
+				if err := dec.DecodeElement(&entry, &start); err != nil {
+					return err
+				}
+				if entry.Value != nil {
+					ti.Data[entry.Key] = entry.Value
+				}
+			default:
+				if StrictMode {
+					return fmt.Errorf("unknown TargetingIdea field %s", tag)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// targetingIdeaEntry is an entry of the data of a targeting idea.
+type targetingIdeaEntry struct {
+	Key   string
+	Value Attribute
+}
+
+func (e *targetingIdeaEntry) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	for token, err := dec.Token(); err == nil; token, err = dec.Token() {
+		if err != nil {
+			return err
+		}
+		switch start := token.(type) {
+		case xml.StartElement:
+			tag := start.Name.Local
+			switch tag {
+			case "key":
+				if err := dec.DecodeElement(&e.Key, &start); err != nil {
+					return err
+				}
+			case "value":
+				value, err := attributeUnmarshalXML(dec, start)
+				if err != nil {
+					return err
+				}
+				e.Value = value
+			default:
+				if StrictMode {
+					return fmt.Errorf("unknown Type_AttributeMapEntry field %s", tag)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// KeywordText returns the KEYWORD_TEXT attribute.
+func (ti TargetingIdea) KeywordText() string {
+	a, _ := ti.Data[AttributeTypeKeywordText].(StringAttribute)
+	return a.Value
+}
+
+// SearchVolume returns the SEARCH_VOLUME attribute, the average number of
+// monthly searches over the last 12 months.
+func (ti TargetingIdea) SearchVolume() int64 {
+	a, _ := ti.Data[AttributeTypeSearchVolume].(LongAttribute)
+	return a.Value
+}
+
+// TargetedMonthlySearches returns the TARGETED_MONTHLY_SEARCHES attribute,
+// the number of searches of each of the last 12 months.
+func (ti TargetingIdea) TargetedMonthlySearches() []MonthlySearchVolume {
+	a, _ := ti.Data[AttributeTypeTargetedMonthlySearches].(MonthlySearchVolumeAttribute)
+	return a.Value
+}
+
+// AverageCpc returns the AVERAGE_CPC attribute in micros.
+func (ti TargetingIdea) AverageCpc() int64 {
+	a, _ := ti.Data[AttributeTypeAverageCpc].(MoneyAttribute)
+	return a.Value
+}
+
+// Competition returns the COMPETITION attribute, between 0 and 1.
+func (ti TargetingIdea) Competition() float64 {
+	a, _ := ti.Data[AttributeTypeCompetition].(DoubleAttribute)
+	return a.Value
+}
+
+// Get returns the targeting ideas matching the selector and their total
+// number.
+//
+// Example
+//
+//   ideas, totalCount, err := targetIdeaService.Get(
+//     TargetingIdeaSelector{
+//       SearchParameters: []SearchParameter{
+//         RelatedToQuerySearchParameter{Queries: []string{"mars cruise"}},
+//         LanguageSearchParameter{Languages: []LanguageCriterion{{Id: 1000}}},
+//         LocationSearchParameter{Locations: []Location{{Id: 2840}}},
+//         NetworkSearchParameter{NetworkSetting: NetworkSetting{TargetGoogleSearch: true}},
+//       },
+//       IdeaType:    "KEYWORD",
+//       RequestType: RequestTypeIdeas,
+//       RequestedAttributeTypes: []string{
+//         AttributeTypeKeywordText, AttributeTypeSearchVolume, AttributeTypeAverageCpc,
+//       },
+//       Paging: &Paging{Offset: 0, Limit: 100},
+//     },
+//   )
+//   for _, idea := range ideas {
+//     fmt.Println(idea.KeywordText(), idea.SearchVolume())
+//   }
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/TargetingIdeaService#get
+//
+func (s *TargetIdeaService) Get(selector TargetingIdeaSelector) (ideas []TargetingIdea, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *TargetIdeaService) GetContext(ctx context.Context, selector TargetingIdeaSelector) (ideas []TargetingIdea, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	params := make([]SearchParameter, len(selector.SearchParameters))
+	for i, p := range selector.SearchParameters {
+		// the languages and locations are cm types
+		switch p := p.(type) {
+		case LanguageSearchParameter:
+			p.Languages = cmXSITypes(p.Languages)
+			params[i] = p
+		case LocationSearchParameter:
+			p.Locations = cmXSITypes(p.Locations)
+			params[i] = p
+		default:
+			params[i] = p
+		}
+	}
+	selector.SearchParameters = params
+	respBody, err := s.Auth.request(
+		ctx,
+		targetingIdeaServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Cm      string `xml:"xmlns:cm,attr"`
+			Sel     TargetingIdeaSelector
+		}{
+			XMLName: xml.Name{
+				Space: optimizationUrl,
+				Local: "get",
+			},
+			Cm:  baseUrl,
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return ideas, totalCount, err
+	}
+	getResp := struct {
+		Size  int64           `xml:"rval>totalNumEntries"`
+		Ideas []TargetingIdea `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return ideas, totalCount, err
+	}
+	return getResp.Ideas, getResp.Size, err
+}
+
+// Pager returns a Pager walking all the ideas matching the selector, see
+// NewPager.
+//
+// Example
+//
+//   pager := targetIdeaService.Pager(ctx, selector)
+//   for pager.Next() {
+//     idea := pager.Entry()
+//     fmt.Println(idea.KeywordText(), idea.Competition())
+//   }
+//   if err := pager.Err(); err != nil {
+//     log.Fatal(err)
+//   }
+//
+func (s *TargetIdeaService) Pager(ctx context.Context, selector TargetingIdeaSelector) *Pager[TargetingIdea] {
+	get := func(ctx context.Context, sel Selector) ([]TargetingIdea, int64, error) {
+		page := selector
+		page.Paging = sel.Paging
+		return s.GetContext(ctx, page)
+	}
+	return NewPager(ctx, get, Selector{Paging: selector.Paging})
+}
@@ -0,0 +1,107 @@
+package gads
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testTargetingIdeaGetResponse = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <getResponse xmlns="https://adwords.google.com/api/adwords/o/v201809" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+      <rval>
+        <totalNumEntries>3</totalNumEntries>
+        %s
+      </rval>
+    </getResponse>
+  </soap:Body>
+</soap:Envelope>`
+
+const testTargetingIdeaEntry = `<entries>
+  <data><key>KEYWORD_TEXT</key><value xsi:type="StringAttribute"><Attribute.Type>StringAttribute</Attribute.Type><value>%s</value></value></data>
+  <data><key>SEARCH_VOLUME</key><value xsi:type="LongAttribute"><Attribute.Type>LongAttribute</Attribute.Type><value>1300</value></value></data>
+  <data><key>TARGETED_MONTHLY_SEARCHES</key><value xsi:type="MonthlySearchVolumeAttribute"><Attribute.Type>MonthlySearchVolumeAttribute</Attribute.Type>
+    <value><year>2018</year><month>9</month><count>1000</count></value>
+    <value><year>2018</year><month>10</month><count>1600</count></value>
+  </value></data>
+  <data><key>AVERAGE_CPC</key><value xsi:type="MoneyAttribute"><Attribute.Type>MoneyAttribute</Attribute.Type><value><ComparableValue.Type>Money</ComparableValue.Type><microAmount>2450000</microAmount></value></value></data>
+  <data><key>COMPETITION</key><value xsi:type="DoubleAttribute"><Attribute.Type>DoubleAttribute</Attribute.Type><value>0.87</value></value></data>
+  <data><key>UNKNOWN</key><value xsi:type="FutureAttribute"><value><nested>1</nested></value></value></data>
+</entries>`
+
+func TestTargetingIdeaService(t *testing.T) {
+	var requests []string
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, string(body))
+		switch {
+		case strings.Contains(string(body), "<startIndex>0</startIndex>"):
+			fmt.Fprintf(w, testTargetingIdeaGetResponse, fmt.Sprintf(testTargetingIdeaEntry, "mars cruise")+fmt.Sprintf(testTargetingIdeaEntry, "mars trip"))
+		default:
+			fmt.Fprintf(w, testTargetingIdeaGetResponse, fmt.Sprintf(testTargetingIdeaEntry, "moon cruise"))
+		}
+	})
+
+	minimum := int64(100)
+	pager := NewTargetIdeaService(&auth).Pager(context.Background(), TargetingIdeaSelector{
+		SearchParameters: []SearchParameter{
+			RelatedToQuerySearchParameter{Queries: []string{"mars cruise"}},
+			LanguageSearchParameter{Languages: []LanguageCriterion{{Id: 1000}}},
+			LocationSearchParameter{Locations: []Location{{Id: 2840}}},
+			NetworkSearchParameter{NetworkSetting: NetworkSetting{TargetGoogleSearch: true}},
+			SearchVolumeSearchParameter{Operation: LongComparisonOperation{Minimum: &minimum}},
+			CompetitionSearchParameter{Levels: []string{"LOW", "MEDIUM"}},
+		},
+		IdeaType:    "KEYWORD",
+		RequestType: RequestTypeIdeas,
+		RequestedAttributeTypes: []string{
+			AttributeTypeKeywordText, AttributeTypeSearchVolume, AttributeTypeTargetedMonthlySearches,
+			AttributeTypeAverageCpc, AttributeTypeCompetition,
+		},
+		Paging: &Paging{Limit: 2},
+	})
+	var keywords []string
+	for pager.Next() {
+		idea := pager.Entry()
+		keywords = append(keywords, idea.KeywordText())
+		if idea.SearchVolume() != 1300 || idea.AverageCpc() != 2450000 || idea.Competition() != 0.87 {
+			t.Errorf("unexpected idea %+v", idea)
+		}
+		if searches := idea.TargetedMonthlySearches(); len(searches) != 2 || searches[1] != (MonthlySearchVolume{2018, 10, 1600}) {
+			t.Errorf("unexpected monthly searches %+v", searches)
+		}
+		if _, ok := idea.Data["UNKNOWN"]; ok {
+			t.Errorf("unknown attribute decoded %+v", idea.Data)
+		}
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(keywords, ",") != "mars cruise,mars trip,moon cruise" {
+		t.Errorf("unexpected keywords %v", keywords)
+	}
+
+	if len(requests) != 2 || !strings.Contains(requests[1], "<startIndex>2</startIndex>") {
+		t.Fatalf("unexpected requests %v", requests)
+	}
+	for _, want := range []string{
+		`<get xmlns="https://adwords.google.com/api/adwords/o/v201809" xmlns:cm="https://adwords.google.com/api/adwords/cm/v201809">`,
+		`xsi:type="RelatedToQuerySearchParameter"`,
+		`<queries>mars cruise</queries>`,
+		`xsi:type="LanguageSearchParameter"`,
+		`xsi:type="LocationSearchParameter"`,
+		`<languages xsi:type="cm:Language">`,
+		`<locations xsi:type="cm:Location">`,
+		`<targetGoogleSearch>true</targetGoogleSearch>`,
+		`<minimum>100</minimum>`,
+		`<levels>MEDIUM</levels>`,
+		`<requestedAttributeTypes>TARGETED_MONTHLY_SEARCHES</requestedAttributeTypes>`,
+	} {
+		if !strings.Contains(requests[0], want) {
+			t.Errorf("%s not sent in %s", want, requests[0])
+		}
+	}
+}
@@ -1,5 +1,11 @@
 package gads
 
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+)
+
 type TrafficEstimatorService struct {
 	Auth
 }
@@ -7,3 +13,241 @@ type TrafficEstimatorService struct {
 func NewTrafficEstimatorService(auth *Auth) *TrafficEstimatorService {
 	return &TrafficEstimatorService{Auth: *auth}
 }
+
+// KeywordEstimateRequest asks for the traffic of a keyword, MaxCpc
+// overrides the one of the ad group.
+type KeywordEstimateRequest struct {
+	Keyword    KeywordCriterion `xml:"keyword"`
+	MaxCpc     *int64           `xml:"maxCpc>microAmount,omitempty"`
+	IsNegative bool             `xml:"isNegative,omitempty"`
+}
+
+// AdGroupEstimateRequest asks for the traffic of the keywords of an ad
+// group, new when AdGroupId is 0.
+type AdGroupEstimateRequest struct {
+	AdGroupId               int64                    `xml:"adGroupId,omitempty"`
+	KeywordEstimateRequests []KeywordEstimateRequest `xml:"keywordEstimateRequests"`
+	MaxCpc                  *int64                   `xml:"maxCpc>microAmount,omitempty"`
+}
+
+// CampaignEstimateRequest asks for the traffic of the ad groups of a
+// campaign, new when CampaignId is 0. Criteria are the Location and
+// LanguageCriterion the campaign targets.
+type CampaignEstimateRequest struct {
+	CampaignId              int64                    `xml:"campaignId,omitempty"`
+	AdGroupEstimateRequests []AdGroupEstimateRequest `xml:"adGroupEstimateRequests"`
+	Criteria                []Criterion              `xml:"criteria,omitempty"`
+	NetworkSetting          *NetworkSetting          `xml:"networkSetting,omitempty"`
+	DailyBudget             *int64                   `xml:"dailyBudget>microAmount,omitempty"`
+}
+
+// TrafficEstimatorSelector lists the campaigns whose traffic is estimated,
+// PlatformEstimateRequested adds the estimates of each platform to the
+// campaign estimates.
+type TrafficEstimatorSelector struct {
+	XMLName                   xml.Name
+	CampaignEstimateRequests  []CampaignEstimateRequest `xml:"campaignEstimateRequests"`
+	PlatformEstimateRequested bool                      `xml:"platformEstimateRequested,omitempty"`
+}
+
+// StatsEstimate is a daily traffic estimate, amounts are in micros.
+type StatsEstimate struct {
+	AverageCpc        int64   `xml:"averageCpc>microAmount"`
+	AveragePosition   float64 `xml:"averagePosition"`
+	ClickThroughRate  float64 `xml:"clickThroughRate"`
+	ClicksPerDay      float64 `xml:"clicksPerDay"`
+	ImpressionsPerDay float64 `xml:"impressionsPerDay"`
+	TotalCost         int64   `xml:"totalCost>microAmount"`
+}
+
+// KeywordEstimate bounds the traffic of a keyword, in the order of the
+// keyword estimate requests.
+type KeywordEstimate struct {
+	CriterionId int64         `xml:"criterionId"`
+	Min         StatsEstimate `xml:"min"`
+	Max         StatsEstimate `xml:"max"`
+}
+
+type AdGroupEstimate struct {
+	AdGroupId        int64             `xml:"adGroupId"`
+	KeywordEstimates []KeywordEstimate `xml:"keywordEstimates"`
+}
+
+// PlatformCampaignEstimate bounds the traffic of a campaign on a platform.
+type PlatformCampaignEstimate struct {
+	Platform    PlatformCriterion `xml:"platform"`
+	MinEstimate StatsEstimate     `xml:"minEstimate"`
+	MaxEstimate StatsEstimate     `xml:"maxEstimate"`
+}
+
+type CampaignEstimate struct {
+	CampaignId        int64                      `xml:"campaignId"`
+	AdGroupEstimates  []AdGroupEstimate          `xml:"adGroupEstimates"`
+	PlatformEstimates []PlatformCampaignEstimate `xml:"platformEstimates"`
+}
+
+// Get returns the estimates of the campaigns of the selector, in the order
+// of the requests.
+//
+// Example
+//
+//   maxCpc := int64(1000000)
+//   estimates, err := trafficEstimatorService.Get(
+//     TrafficEstimatorSelector{
+//       CampaignEstimateRequests: []CampaignEstimateRequest{
+//         {
+//           AdGroupEstimateRequests: []AdGroupEstimateRequest{
+//             {
+//               KeywordEstimateRequests: []KeywordEstimateRequest{
+//                 {Keyword: KeywordCriterion{Text: "mars cruise", MatchType: "BROAD"}},
+//                 {Keyword: KeywordCriterion{Text: "cheap cruise", MatchType: "EXACT"}},
+//               },
+//               MaxCpc: &maxCpc,
+//             },
+//           },
+//           Criteria: []Criterion{
+//             Location{Id: 2840},
+//             LanguageCriterion{Id: 1000},
+//           },
+//         },
+//       },
+//     },
+//   )
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/TrafficEstimatorService#get
+//
+func (s *TrafficEstimatorService) Get(selector TrafficEstimatorSelector) (campaignEstimates []CampaignEstimate, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *TrafficEstimatorService) GetContext(ctx context.Context, selector TrafficEstimatorSelector) (campaignEstimates []CampaignEstimate, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	// the keywords and criteria are cm types
+	campaigns := make([]CampaignEstimateRequest, len(selector.CampaignEstimateRequests))
+	for i, campaign := range selector.CampaignEstimateRequests {
+		campaign.Criteria = cmXSITypes(campaign.Criteria)
+		adGroups := make([]AdGroupEstimateRequest, len(campaign.AdGroupEstimateRequests))
+		for j, adGroup := range campaign.AdGroupEstimateRequests {
+			keywords := make([]KeywordEstimateRequest, len(adGroup.KeywordEstimateRequests))
+			for k, keyword := range adGroup.KeywordEstimateRequests {
+				keyword.Keyword = cmXSIType(keyword.Keyword).(KeywordCriterion)
+				keywords[k] = keyword
+			}
+			adGroup.KeywordEstimateRequests = keywords
+			adGroups[j] = adGroup
+		}
+		campaign.AdGroupEstimateRequests = adGroups
+		campaigns[i] = campaign
+	}
+	selector.CampaignEstimateRequests = campaigns
+	respBody, err := s.Auth.request(
+		ctx,
+		trafficEstimatorServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Cm      string `xml:"xmlns:cm,attr"`
+			Sel     TrafficEstimatorSelector
+		}{
+			XMLName: xml.Name{
+				Space: optimizationUrl,
+				Local: "get",
+			},
+			Cm:  baseUrl,
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return campaignEstimates, err
+	}
+	getResp := struct {
+		CampaignEstimates []CampaignEstimate `xml:"rval>campaignEstimates"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return campaignEstimates, err
+	}
+	return getResp.CampaignEstimates, err
+}
+
+// BidCurvePoint is the traffic of the keywords of a bid curve at MaxCpc,
+// Min and Max summing the estimates of all the keywords.
+type BidCurvePoint struct {
+	MaxCpc           int64
+	Min              StatsEstimate
+	Max              StatsEstimate
+	KeywordEstimates []KeywordEstimate
+}
+
+// BidCurve estimates the traffic of the single ad group of request at each
+// of the max CPCs given in micros, in a single call. The max CPCs of the
+// ad group and of its keywords are overridden.
+//
+// Example
+//
+//   curve, err := trafficEstimatorService.BidCurve(ctx, request, []int64{250000, 500000, 1000000, 2000000})
+//   for _, point := range curve {
+//     fmt.Println(point.MaxCpc, point.Max.ClicksPerDay, point.Max.TotalCost)
+//   }
+//
+func (s *TrafficEstimatorService) BidCurve(ctx context.Context, request CampaignEstimateRequest, maxCpcs []int64) (curve []BidCurvePoint, err error) {
+	if len(request.AdGroupEstimateRequests) != 1 {
+		return curve, fmt.Errorf("gads: a bid curve needs 1 ad group estimate request, got %d", len(request.AdGroupEstimateRequests))
+	}
+	template := request.AdGroupEstimateRequests[0]
+	request.AdGroupEstimateRequests = nil
+	for i := range maxCpcs {
+		adGroup := template
+		adGroup.MaxCpc = &maxCpcs[i]
+		adGroup.KeywordEstimateRequests = make([]KeywordEstimateRequest, len(template.KeywordEstimateRequests))
+		for j, keyword := range template.KeywordEstimateRequests {
+			keyword.MaxCpc = nil
+			adGroup.KeywordEstimateRequests[j] = keyword
+		}
+		request.AdGroupEstimateRequests = append(request.AdGroupEstimateRequests, adGroup)
+	}
+
+	campaignEstimates, err := s.GetContext(ctx, TrafficEstimatorSelector{
+		CampaignEstimateRequests: []CampaignEstimateRequest{request},
+	})
+	if err != nil {
+		return curve, err
+	}
+	if len(campaignEstimates) != 1 || len(campaignEstimates[0].AdGroupEstimates) != len(maxCpcs) {
+		return curve, fmt.Errorf("gads: unexpected traffic estimates %+v", campaignEstimates)
+	}
+	for i, adGroup := range campaignEstimates[0].AdGroupEstimates {
+		point := BidCurvePoint{MaxCpc: maxCpcs[i], KeywordEstimates: adGroup.KeywordEstimates}
+		var min, max []StatsEstimate
+		for _, keyword := range adGroup.KeywordEstimates {
+			min = append(min, keyword.Min)
+			max = append(max, keyword.Max)
+		}
+		point.Min, point.Max = sumStatsEstimates(min), sumStatsEstimates(max)
+		curve = append(curve, point)
+	}
+	return curve, nil
+}
+
+// sumStatsEstimates sums the clicks, impressions and cost of estimates,
+// the averages are weighted accordingly.
+func sumStatsEstimates(estimates []StatsEstimate) (sum StatsEstimate) {
+	var positions float64
+	for _, e := range estimates {
+		sum.ClicksPerDay += e.ClicksPerDay
+		sum.ImpressionsPerDay += e.ImpressionsPerDay
+		sum.TotalCost += e.TotalCost
+		positions += e.AveragePosition * e.ImpressionsPerDay
+	}
+	if sum.ClicksPerDay > 0 {
+		sum.AverageCpc = int64(float64(sum.TotalCost) / sum.ClicksPerDay)
+	}
+	if sum.ImpressionsPerDay > 0 {
+		sum.ClickThroughRate = sum.ClicksPerDay / sum.ImpressionsPerDay
+		sum.AveragePosition = positions / sum.ImpressionsPerDay
+	}
+	return sum
+}
@@ -0,0 +1,85 @@
+package gads
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func testStatsEstimate(clicks, impressions float64, cost int64) string {
+	return fmt.Sprintf(`<averagePosition>2</averagePosition><clicksPerDay>%g</clicksPerDay><impressionsPerDay>%g</impressionsPerDay><totalCost><microAmount>%d</microAmount></totalCost>`, clicks, impressions, cost)
+}
+
+func TestTrafficEstimatorBidCurve(t *testing.T) {
+	var sent string
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		sent = string(body)
+		var adGroups string
+		for i := 1; i <= 2; i++ {
+			adGroups += `<adGroupEstimates>`
+			for j := 1; j <= 2; j++ {
+				adGroups += fmt.Sprintf(`<keywordEstimates><min>%s</min><max>%s</max></keywordEstimates>`,
+					testStatsEstimate(float64(i*j), float64(10*i*j), int64(i*j*100000)),
+					testStatsEstimate(float64(2*i*j), float64(20*i*j), int64(2*i*j*100000)))
+			}
+			adGroups += `</adGroupEstimates>`
+		}
+		fmt.Fprintf(w, `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>
+<getResponse xmlns="https://adwords.google.com/api/adwords/o/v201809"><rval><campaignEstimates>%s</campaignEstimates></rval></getResponse>
+</soap:Body></soap:Envelope>`, adGroups)
+	})
+
+	keywordCpc := int64(9000000)
+	request := CampaignEstimateRequest{
+		AdGroupEstimateRequests: []AdGroupEstimateRequest{{
+			KeywordEstimateRequests: []KeywordEstimateRequest{
+				{Keyword: KeywordCriterion{Text: "mars cruise", MatchType: "BROAD"}, MaxCpc: &keywordCpc},
+				{Keyword: KeywordCriterion{Text: "cheap cruise", MatchType: "EXACT"}},
+			},
+		}},
+		Criteria: []Criterion{Location{Id: 2840}, LanguageCriterion{Id: 1000}},
+	}
+	curve, err := NewTrafficEstimatorService(&auth).BidCurve(context.Background(), request, []int64{500000, 1000000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`<get xmlns="https://adwords.google.com/api/adwords/o/v201809" xmlns:cm="https://adwords.google.com/api/adwords/cm/v201809">`,
+		`<keyword xsi:type="cm:Keyword">`,
+		`<text>mars cruise</text>`,
+		`<criteria xsi:type="cm:Location">`,
+		`<criteria xsi:type="cm:Language">`,
+		`<microAmount>500000</microAmount>`,
+		`<microAmount>1000000</microAmount>`,
+	} {
+		if !strings.Contains(sent, want) {
+			t.Errorf("%s not sent in %s", want, sent)
+		}
+	}
+	if strings.Contains(sent, "9000000") || strings.Count(sent, "<adGroupEstimateRequests>") != 2 {
+		t.Errorf("unexpected ad group requests in %s", sent)
+	}
+	if request.AdGroupEstimateRequests[0].KeywordEstimateRequests[0].MaxCpc != &keywordCpc {
+		t.Error("request modified")
+	}
+
+	if len(curve) != 2 || curve[0].MaxCpc != 500000 || curve[1].MaxCpc != 1000000 {
+		t.Fatalf("unexpected curve %+v", curve)
+	}
+	want := StatsEstimate{AverageCpc: 100000, AveragePosition: 2, ClickThroughRate: 0.1, ClicksPerDay: 6, ImpressionsPerDay: 60, TotalCost: 600000}
+	if curve[1].Min != want || len(curve[1].KeywordEstimates) != 2 {
+		t.Errorf("unexpected point %+v", curve[1])
+	}
+	if curve[0].Max.ClicksPerDay != 6 || curve[0].Max.TotalCost != 600000 {
+		t.Errorf("unexpected point %+v", curve[0])
+	}
+
+	if _, err := NewTrafficEstimatorService(&auth).BidCurve(context.Background(), CampaignEstimateRequest{}, []int64{1}); err == nil {
+		t.Error("expected an error without ad group")
+	}
+}
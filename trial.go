@@ -0,0 +1,346 @@
+package gads
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+type TrialService struct {
+	Auth
+}
+
+func NewTrialService(auth *Auth) *TrialService {
+	return &TrialService{Auth: *auth}
+}
+
+// Trial statuses
+const (
+	TrialStatusCreating        = "CREATING"
+	TrialStatusActive          = "ACTIVE"
+	TrialStatusPaused          = "PAUSED"
+	TrialStatusCreationFailed  = "CREATION_FAILED"
+	TrialStatusGraduated       = "GRADUATED"
+	TrialStatusPromoting       = "PROMOTING"
+	TrialStatusPromoted        = "PROMOTED"
+	TrialStatusPromotionFailed = "PROMOTION_FAILED"
+	TrialStatusHalted          = "HALTED"
+	TrialStatusArchived        = "ARCHIVED"
+)
+
+// Trial traffic split types
+const (
+	TrafficSplitTypeRandomQuery = "RANDOM_QUERY"
+	TrafficSplitTypeCookie      = "COOKIE"
+)
+
+// Trial serves the changes of a draft to TrafficSplitPercent percent of
+// the traffic of its base campaign, through a trial campaign whose
+// CampaignTrialType is TRIAL. BudgetId is the budget the trial campaign
+// graduates with.
+type Trial struct {
+	Id                  int64  `xml:"id,omitempty"`
+	BaseCampaignId      int64  `xml:"baseCampaignId,omitempty"`
+	DraftId             int64  `xml:"draftId,omitempty"`
+	BudgetId            int64  `xml:"budgetId,omitempty"`
+	Name                string `xml:"name,omitempty"`
+	StartDate           string `xml:"startDate,omitempty"`
+	EndDate             string `xml:"endDate,omitempty"`
+	TrafficSplitPercent int    `xml:"trafficSplitPercent,omitempty"`
+	TrafficSplitType    string `xml:"trafficSplitType,omitempty"` // TrafficSplitType: "RANDOM_QUERY", "COOKIE"
+	TrialCampaignId     int64  `xml:"trialCampaignId,omitempty"`
+	Status              string `xml:"status,omitempty"` // Status: "CREATING", "ACTIVE", "PAUSED", "CREATION_FAILED", "GRADUATED", "PROMOTING", "PROMOTED", "PROMOTION_FAILED", "HALTED", "ARCHIVED"
+}
+
+// TrialOperations is a map of operations to perform on Trial's
+type TrialOperations map[string][]Trial
+
+// TrialFields is the catalog of the fields accepted by the selectors of
+// TrialService.Get, see NewSelector.
+var TrialFields = FieldCatalog{
+	Service: "TrialService",
+	Selectable: []string{
+		"BaseCampaignId", "BudgetId", "DraftId", "EndDate", "Id", "Name",
+		"StartDate", "Status", "TrafficSplitPercent", "TrafficSplitType",
+		"TrialCampaignId",
+	},
+	Filterable: []string{
+		"BaseCampaignId", "DraftId", "EndDate", "Id", "Name", "StartDate",
+		"Status", "TrafficSplitPercent", "TrafficSplitType", "TrialCampaignId",
+	},
+	Complete: true,
+}
+
+// Get returns an array of Trial's and the total number of Trial's
+// matching the selector.
+//
+// Example
+//
+//   trials, totalCount, err := trialService.Get(
+//     Selector{
+//       Fields: []string{"Id","Name","Status","TrialCampaignId","TrafficSplitPercent"},
+//       Predicates: []Predicate{
+//         {"BaseCampaignId", "EQUALS", []string{"1234"}},
+//       },
+//     },
+//   )
+//
+// Selectable fields are
+//   "BaseCampaignId", "BudgetId", "DraftId", "EndDate", "Id", "Name",
+//   "StartDate", "Status", "TrafficSplitPercent", "TrafficSplitType",
+//   "TrialCampaignId"
+//
+// filterable fields are
+//   "BaseCampaignId", "DraftId", "EndDate", "Id", "Name", "StartDate",
+//   "Status", "TrafficSplitPercent", "TrafficSplitType", "TrialCampaignId"
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/TrialService#get
+//
+func (s *TrialService) Get(selector Selector) (trials []Trial, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *TrialService) GetContext(ctx context.Context, selector Selector) (trials []Trial, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		trialServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return trials, totalCount, err
+	}
+	getResp := struct {
+		Size   int64   `xml:"rval>totalNumEntries"`
+		Trials []Trial `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return trials, totalCount, err
+	}
+	return getResp.Trials, getResp.Size, err
+}
+
+// Mutate allows you to add and modify trials, returning the modified
+// trials. The creation of a trial is asynchronous, see Wait.
+//
+// Example
+//
+//  trials, err := trialService.Mutate(
+//    TrialOperations{
+//      "ADD": {
+//        Trial{
+//          DraftId:             10,
+//          BaseCampaignId:      1234,
+//          Name:                "bid strategy test",
+//          TrafficSplitPercent: 50,
+//          TrafficSplitType:    TrafficSplitTypeCookie,
+//        },
+//      },
+//      "SET": {
+//        Trial{Id: 20, Status: TrialStatusPaused},
+//      },
+//    },
+//  )
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/TrialService#mutate
+//
+func (s *TrialService) Mutate(trialOperations TrialOperations) (trials []Trial, err error) {
+	return s.MutateContext(context.Background(), trialOperations)
+}
+
+// MutateContext is like Mutate but binds the request to ctx.
+func (s *TrialService) MutateContext(ctx context.Context, trialOperations TrialOperations) (trials []Trial, err error) {
+	type trialOperation struct {
+		Action string `xml:"operator"`
+		Trial  Trial  `xml:"operand"`
+	}
+	operations := []trialOperation{}
+	for action, trials := range trialOperations {
+		for _, trial := range trials {
+			operations = append(operations,
+				trialOperation{
+					Action: action,
+					Trial:  trial,
+				},
+			)
+		}
+	}
+	mutation := struct {
+		XMLName xml.Name
+		Ops     []trialOperation `xml:"operations"`
+	}{
+		XMLName: xml.Name{
+			Space: baseUrl,
+			Local: "mutate",
+		},
+		Ops: operations,
+	}
+	respBody, err := s.Auth.request(ctx, trialServiceUrl, "mutate", mutation)
+	if err != nil {
+		return trials, err
+	}
+	mutateResp := struct {
+		BaseResponse
+		Trials []Trial `xml:"rval>value"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &mutateResp)
+	if err != nil {
+		return trials, err
+	}
+
+	if len(mutateResp.PartialFailureErrors) > 0 {
+		err = mutateResp.PartialFailureErrors
+	}
+
+	return mutateResp.Trials, err
+}
+
+// Query returns the trials matching an AWQL query, see Select and
+// AWQLStatement to build it.
+//
+// Example
+//
+//   trials, totalCount, err := trialService.Query("SELECT Id, Status WHERE BaseCampaignId = 1234")
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/TrialService#query
+//
+func (s *TrialService) Query(query string) (trials []Trial, totalCount int64, err error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *TrialService) QueryContext(ctx context.Context, query string) (trials []Trial, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		trialServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return trials, totalCount, err
+	}
+	queryResp := struct {
+		Size   int64   `xml:"rval>totalNumEntries"`
+		Trials []Trial `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return trials, totalCount, err
+	}
+	return queryResp.Trials, queryResp.Size, err
+}
+
+// mutateTrial performs a single operation and returns its trial.
+func (s *TrialService) mutateTrial(ctx context.Context, action string, trial Trial) (Trial, error) {
+	trials, err := s.MutateContext(ctx, TrialOperations{action: {trial}})
+	if err != nil {
+		return trial, err
+	}
+	if len(trials) == 0 {
+		return trial, errors.New("gads: no trial returned")
+	}
+	return trials[0], nil
+}
+
+// Create starts creating a trial of the changes of a draft, the trial
+// campaign is created asynchronously, see Wait.
+//
+// Example
+//
+//   trial, err := trialService.Create(ctx, Trial{
+//     DraftId:             draft.DraftId,
+//     BaseCampaignId:      draft.BaseCampaignId,
+//     Name:                "bid strategy test",
+//     TrafficSplitPercent: 50,
+//     TrafficSplitType:    TrafficSplitTypeCookie,
+//   })
+//   if err != nil {
+//     log.Fatal(err)
+//   }
+//   trial, err = trialService.Wait(ctx, trial.Id, nil)
+//   if errors.Is(err, ErrTrialFailed) {
+//     errs, _ := trialAsyncErrorService.ForTrial(ctx, trial.Id)
+//     log.Fatal(errs)
+//   }
+//
+func (s *TrialService) Create(ctx context.Context, trial Trial) (Trial, error) {
+	return s.mutateTrial(ctx, "ADD", trial)
+}
+
+// Graduate turns the trial campaign into a regular campaign spending
+// budgetId, independent of the base campaign.
+func (s *TrialService) Graduate(ctx context.Context, trial Trial, budgetId int64) (Trial, error) {
+	return s.mutateTrial(ctx, "SET", Trial{
+		Id:       trial.Id,
+		BudgetId: budgetId,
+		Status:   TrialStatusGraduated,
+	})
+}
+
+// Promote starts applying the changes of the trial to its base campaign,
+// the promotion is asynchronous, see Wait.
+func (s *TrialService) Promote(ctx context.Context, trial Trial) (Trial, error) {
+	return s.mutateTrial(ctx, "SET", Trial{
+		Id:     trial.Id,
+		Status: TrialStatusPromoting,
+	})
+}
+
+// Wait polls the trial id until it is neither being created nor promoted,
+// and returns it. ErrTrialFailed is returned along with trials whose
+// creation or promotion failed, TrialAsyncErrorService tells why.
+func (s *TrialService) Wait(ctx context.Context, id int64, polling *Polling) (trial Trial, err error) {
+	selector := Selector{
+		Fields: TrialFields.Selectable,
+		Predicates: []Predicate{
+			{"Id", "EQUALS", []string{strconv.FormatInt(id, 10)}},
+		},
+	}
+	for attempt := 1; ; attempt++ {
+		if err := polling.wait(ctx, attempt); err != nil {
+			return trial, err
+		}
+
+		trials, _, err := s.GetContext(ctx, selector)
+		if err != nil {
+			return trial, err
+		}
+		if len(trials) == 0 {
+			return trial, fmt.Errorf("gads: trial %d not found", id)
+		}
+		trial = trials[0]
+		switch trial.Status {
+		case TrialStatusCreating, TrialStatusPromoting:
+		case TrialStatusCreationFailed, TrialStatusPromotionFailed:
+			return trial, ErrTrialFailed
+		default:
+			return trial, nil
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package gads
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+type TrialAsyncErrorService struct {
+	Auth
+}
+
+func NewTrialAsyncErrorService(auth *Auth) *TrialAsyncErrorService {
+	return &TrialAsyncErrorService{Auth: *auth}
+}
+
+// TrialAsyncError is an error of the asynchronous creation or promotion
+// of a trial.
+type TrialAsyncError struct {
+	TrialId    int64
+	AsyncError ApiError
+}
+
+func (e *TrialAsyncError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	for token, err := dec.Token(); err == nil; token, err = dec.Token() {
+		if err != nil {
+			return err
+		}
+		switch start := token.(type) {
+		case xml.StartElement:
+			tag := start.Name.Local
+			switch tag {
+			case "trialId":
+				if err := dec.DecodeElement(&e.TrialId, &start); err != nil {
+					return err
+				}
+			case "asyncError":
+				asyncError, err := decodeApiError(dec, start)
+				if err != nil {
+					return err
+				}
+				e.AsyncError = asyncError
+			default:
+				if StrictMode {
+					return fmt.Errorf("unknown TrialAsyncError field %s", tag)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// TrialAsyncErrorFields is the catalog of the fields accepted by the selectors of
+// TrialAsyncErrorService.Get, see NewSelector.
+var TrialAsyncErrorFields = FieldCatalog{
+	Service: "TrialAsyncErrorService",
+	Selectable: []string{
+		"AsyncError", "TrialId",
+	},
+	Filterable: []string{
+		"TrialId",
+	},
+	Complete: true,
+}
+
+// Get returns an array of TrialAsyncError's and the total number of TrialAsyncError's
+// matching the selector.
+//
+// Example
+//
+//   trialAsyncErrors, totalCount, err := trialAsyncErrorService.Get(
+//     Selector{
+//       Fields: []string{"TrialId","AsyncError"},
+//       Predicates: []Predicate{
+//         {"TrialId", "EQUALS", []string{"20"}},
+//       },
+//     },
+//   )
+//
+// Selectable fields are
+//   "AsyncError", "TrialId"
+//
+// filterable fields are
+//   "TrialId"
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/TrialAsyncErrorService#get
+//
+func (s *TrialAsyncErrorService) Get(selector Selector) (trialAsyncErrors []TrialAsyncError, totalCount int64, err error) {
+	return s.GetContext(context.Background(), selector)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *TrialAsyncErrorService) GetContext(ctx context.Context, selector Selector) (trialAsyncErrors []TrialAsyncError, totalCount int64, err error) {
+	selector.XMLName = xml.Name{"", "selector"}
+	respBody, err := s.Auth.request(
+		ctx,
+		trialAsyncErrorServiceUrl,
+		"get",
+		struct {
+			XMLName xml.Name
+			Sel     Selector
+		}{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "get",
+			},
+			Sel: selector,
+		},
+	)
+	if err != nil {
+		return trialAsyncErrors, totalCount, err
+	}
+	getResp := struct {
+		Size             int64             `xml:"rval>totalNumEntries"`
+		TrialAsyncErrors []TrialAsyncError `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &getResp)
+	if err != nil {
+		return trialAsyncErrors, totalCount, err
+	}
+	return getResp.TrialAsyncErrors, getResp.Size, err
+}
+
+// Query returns the trial async errors matching an AWQL query, see Select
+// and AWQLStatement to build it.
+//
+// Example
+//
+//   trialAsyncErrors, totalCount, err := trialAsyncErrorService.Query("SELECT TrialId, AsyncError WHERE TrialId = 20")
+//
+// Relevant documentation
+//
+//     https://developers.google.com/adwords/api/docs/reference/v201809/TrialAsyncErrorService#query
+//
+func (s *TrialAsyncErrorService) Query(query string) (trialAsyncErrors []TrialAsyncError, totalCount int64, err error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query but binds the request to ctx.
+func (s *TrialAsyncErrorService) QueryContext(ctx context.Context, query string) (trialAsyncErrors []TrialAsyncError, totalCount int64, err error) {
+	respBody, err := s.Auth.request(
+		ctx,
+		trialAsyncErrorServiceUrl,
+		"query",
+		AWQLQuery{
+			XMLName: xml.Name{
+				Space: baseUrl,
+				Local: "query",
+			},
+			Query: query,
+		},
+	)
+	if err != nil {
+		return trialAsyncErrors, totalCount, err
+	}
+	queryResp := struct {
+		Size             int64             `xml:"rval>totalNumEntries"`
+		TrialAsyncErrors []TrialAsyncError `xml:"rval>entries"`
+	}{}
+	err = xml.Unmarshal([]byte(respBody), &queryResp)
+	if err != nil {
+		return trialAsyncErrors, totalCount, err
+	}
+	return queryResp.TrialAsyncErrors, queryResp.Size, err
+}
+
+// ForTrial returns the errors of the creation or promotion of the trial
+// id, once Wait returned ErrTrialFailed.
+func (s *TrialAsyncErrorService) ForTrial(ctx context.Context, id int64) (errs []ApiError, err error) {
+	pager := NewPager(ctx, s.GetContext, Selector{
+		Fields: TrialAsyncErrorFields.Selectable,
+		Predicates: []Predicate{
+			{"TrialId", "EQUALS", []string{strconv.FormatInt(id, 10)}},
+		},
+	})
+	for pager.Next() {
+		if e := pager.Entry().AsyncError; e != nil {
+			errs = append(errs, e)
+		}
+	}
+	return errs, pager.Err()
+}
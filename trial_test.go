@@ -0,0 +1,118 @@
+package gads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testTrialResponse = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>
+<%[1]sResponse xmlns="https://adwords.google.com/api/adwords/cm/v201809" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><rval>%[2]s</rval></%[1]sResponse>
+</soap:Body></soap:Envelope>`
+
+func TestTrialWorkflow(t *testing.T) {
+	var requests []string
+	polls := 0
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, string(body))
+		switch {
+		case strings.Contains(string(body), "<fields>AsyncError</fields>"):
+			fmt.Fprintf(w, testTrialResponse, "get", `<totalNumEntries>1</totalNumEntries><entries><trialId>20</trialId>
+<asyncError xsi:type="EntityNotFound"><fieldPath></fieldPath><errorString>EntityNotFound.INVALID_ID</errorString><ApiError.Type>EntityNotFound</ApiError.Type><reason>INVALID_ID</reason></asyncError>
+</entries>`)
+		case strings.Contains(string(body), "<operations>"):
+			fmt.Fprintf(w, testTrialResponse, "mutate", `<value><id>20</id><baseCampaignId>1234</baseCampaignId><draftId>10</draftId><status>CREATING</status></value>`)
+		default:
+			polls++
+			status := TrialStatusCreating
+			if polls > 1 {
+				status = TrialStatusCreationFailed
+			}
+			fmt.Fprintf(w, testTrialResponse, "get", `<totalNumEntries>1</totalNumEntries><entries><id>20</id><status>`+status+`</status></entries>`)
+		}
+	})
+
+	ctx := context.Background()
+	trialService := NewTrialService(&auth)
+	trial, err := trialService.Create(ctx, Trial{
+		DraftId:             10,
+		BaseCampaignId:      1234,
+		Name:                "bid strategy test",
+		TrafficSplitPercent: 50,
+		TrafficSplitType:    TrafficSplitTypeCookie,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"<operator>ADD</operator>", "<trafficSplitPercent>50</trafficSplitPercent>", "<trafficSplitType>COOKIE</trafficSplitType>"} {
+		if !strings.Contains(requests[0], want) {
+			t.Errorf("%s not sent in %s", want, requests[0])
+		}
+	}
+
+	trial, err = trialService.Wait(ctx, trial.Id, &Polling{Interval: time.Millisecond})
+	if !errors.Is(err, ErrTrialFailed) || trial.Status != TrialStatusCreationFailed || polls != 2 {
+		t.Fatalf("unexpected trial %+v after %d polls: %v", trial, polls, err)
+	}
+
+	errs, err := NewTrialAsyncErrorService(&auth).ForTrial(ctx, trial.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || errs[0].GetReason() != "INVALID_ID" {
+		t.Errorf("unexpected async errors %+v", errs)
+	}
+	if _, ok := errs[0].(EntityNotFound); !ok {
+		t.Errorf("unexpected async error type %T", errs[0])
+	}
+
+	if _, err := trialService.Graduate(ctx, trial, 55); err != nil {
+		t.Fatal(err)
+	}
+	last := requests[len(requests)-1]
+	for _, want := range []string{"<operator>SET</operator>", "<budgetId>55</budgetId>", "<status>GRADUATED</status>"} {
+		if !strings.Contains(last, want) {
+			t.Errorf("%s not sent in %s", want, last)
+		}
+	}
+}
+
+func TestDraftPromote(t *testing.T) {
+	var requests []string
+	auth := testStubAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, string(body))
+		status := DraftStatusPromoted
+		if len(requests) == 1 {
+			status = DraftStatusPromoting
+		}
+		action := "get"
+		if strings.Contains(string(body), "<operations>") {
+			action = "mutate"
+		}
+		fmt.Fprintf(w, testTrialResponse, action, `<value><baseCampaignId>1234</baseCampaignId><draftId>10</draftId><draftStatus>`+status+`</draftStatus></value>
+<totalNumEntries>1</totalNumEntries><entries><baseCampaignId>1234</baseCampaignId><draftId>10</draftId><draftStatus>`+status+`</draftStatus></entries>`)
+	})
+
+	draftService := NewDraftService(&auth)
+	draft, err := draftService.Promote(context.Background(), Draft{BaseCampaignId: 1234, DraftId: 10, DraftName: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(requests[0], "draftName") || !strings.Contains(requests[0], "<draftStatus>PROMOTING</draftStatus>") {
+		t.Errorf("unexpected promotion %s", requests[0])
+	}
+	draft, err = draftService.Wait(context.Background(), draft, &Polling{Interval: time.Millisecond})
+	if err != nil || draft.DraftStatus != DraftStatusPromoted {
+		t.Fatalf("unexpected draft %+v: %v", draft, err)
+	}
+	if !strings.Contains(requests[1], "<field>DraftId</field>") || !strings.Contains(requests[1], "<field>BaseCampaignId</field>") {
+		t.Errorf("unexpected poll %s", requests[1])
+	}
+}